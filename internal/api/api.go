@@ -0,0 +1,365 @@
+// Package api는 웹 컨트롤러용 HTTP API를 제공합니다.
+// 모든 조작은 Controller를 통해 슬래시 명령어와 같은 재생 로직을 호출합니다.
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/disgolink/v3/lavalink"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/uzih05/discord-music-bot/internal/embed"
+)
+
+// maxBodySize는 요청 본문의 최대 크기입니다.
+const maxBodySize = 64 << 10
+
+// Controller는 API가 호출하는 봇 동작입니다. bot 패키지가 구현합니다.
+type Controller interface {
+	// TokenHash는 길드에 발급된 API 토큰의 해시이며, 발급하지 않았으면 빈 문자열입니다.
+	TokenHash(guildID snowflake.ID) string
+	// Players는 플레이어가 있는 모든 길드의 상태입니다 (대기열 제외).
+	Players() []Player
+	// Player는 길드 플레이어 상태와 대기열입니다. 플레이어가 없으면 false입니다.
+	Player(guildID snowflake.ID) (Player, bool)
+	// Health는 게이트웨이, Lavalink 노드, 명령어 등록 상태입니다.
+	Health() Health
+
+	Enqueue(ctx context.Context, guildID snowflake.ID, req EnqueueRequest) (string, error)
+	Skip(guildID snowflake.ID) (string, error)
+	Pause(guildID snowflake.ID, paused bool) error
+	Seek(guildID snowflake.ID, position string) (lavalink.Duration, error)
+	Volume(guildID snowflake.ID, volume int) error
+	Move(guildID snowflake.ID, from, to int) (lavalink.Track, error)
+	Remove(guildID snowflake.ID, position int) (lavalink.Track, error)
+}
+
+// Error는 HTTP 상태 코드를 지정한 오류입니다. 그 밖의 오류는 500으로 응답합니다.
+type Error struct {
+	Status  int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func Errorf(status int, format string, args ...any) error {
+	return &Error{Status: status, Message: fmt.Sprintf(format, args...)}
+}
+
+type Config struct {
+	Addr string
+	// AdminToken이 있으면 모든 길드에 접근할 수 있는 관리용 토큰으로 씁니다.
+	AdminToken string
+	// AllowOrigin은 브라우저에서 API를 호출하는 웹 컨트롤러의 Origin입니다 (CORS). 비우면 CORS 헤더를 보내지 않습니다.
+	AllowOrigin string
+}
+
+type Server struct {
+	cfg  Config
+	ctrl Controller
+	hub  *Hub
+	mux  *http.ServeMux
+	srv  *http.Server
+	// closing은 Shutdown이 끝나지 않는 이벤트 스트림을 먼저 닫도록 알립니다.
+	closing chan struct{}
+}
+
+func New(cfg Config, ctrl Controller, hub *Hub) *Server {
+	s := &Server{
+		cfg:     cfg,
+		ctrl:    ctrl,
+		hub:     hub,
+		mux:     http.NewServeMux(),
+		closing: make(chan struct{}),
+	}
+	s.routes()
+	s.srv = &http.Server{
+		Addr:              cfg.Addr,
+		Handler:           s.cors(s.mux),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return s
+}
+
+func (s *Server) routes() {
+	// 컨테이너 오케스트레이터가 토큰 없이 확인할 수 있도록 인증하지 않음
+	s.mux.HandleFunc("GET /healthz", s.handleHealthz)
+	s.mux.HandleFunc("GET /readyz", s.handleReadyz)
+	s.mux.HandleFunc("GET /api/v1/players", s.authenticated(s.handlePlayers))
+	s.mux.HandleFunc("GET /api/v1/events", s.authenticated(s.handleEvents))
+	s.mux.HandleFunc("GET /api/v1/guilds/{guild}/player", s.guild(s.handlePlayer))
+	s.mux.HandleFunc("GET /api/v1/guilds/{guild}/queue", s.guild(s.handleQueue))
+	s.mux.HandleFunc("POST /api/v1/guilds/{guild}/queue", s.guild(s.handleEnqueue))
+	s.mux.HandleFunc("POST /api/v1/guilds/{guild}/queue/move", s.guild(s.handleMove))
+	s.mux.HandleFunc("DELETE /api/v1/guilds/{guild}/queue/{position}", s.guild(s.handleRemove))
+	s.mux.HandleFunc("POST /api/v1/guilds/{guild}/skip", s.guild(s.handleSkip))
+	s.mux.HandleFunc("POST /api/v1/guilds/{guild}/pause", s.guild(s.handlePause))
+	s.mux.HandleFunc("POST /api/v1/guilds/{guild}/seek", s.guild(s.handleSeek))
+	s.mux.HandleFunc("POST /api/v1/guilds/{guild}/volume", s.guild(s.handleVolume))
+}
+
+// Handle은 토큰 인증 없이 열리는 경로를 추가합니다. Start 전에 호출해야 합니다.
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+// HandleAdmin은 관리용 토큰(API_ADMIN_TOKEN)으로만 열리는 경로를 추가합니다. Start 전에 호출해야 합니다.
+func (s *Server) HandleAdmin(pattern string, handler http.Handler) {
+	s.mux.HandleFunc(pattern, s.authenticated(func(w http.ResponseWriter, r *http.Request, a access) {
+		if !a.admin {
+			writeError(w, Errorf(http.StatusForbidden, "관리용 토큰이 필요합니다"))
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+}
+
+// Start는 주소를 바로 열어 포트 충돌 등을 반환하고, 요청 처리는 백그라운드에서 합니다.
+func (s *Server) Start() error {
+	ln, err := net.Listen("tcp", s.cfg.Addr)
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := s.srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("API 서버 오류", "error", err)
+		}
+	}()
+	slog.Info("API 서버 시작", "addr", ln.Addr().String())
+	return nil
+}
+
+func (s *Server) Shutdown(ctx context.Context) error {
+	close(s.closing)
+	return s.srv.Shutdown(ctx)
+}
+
+// access는 요청한 토큰이 접근할 수 있는 범위입니다.
+type access struct {
+	admin   bool
+	guildID snowflake.ID
+}
+
+func (a access) allows(guildID snowflake.ID) bool {
+	return a.admin || a.guildID == guildID
+}
+
+func (s *Server) authorize(r *http.Request) (access, bool) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return access{}, false
+	}
+	if s.cfg.AdminToken != "" && equalSecret(token, s.cfg.AdminToken) {
+		return access{admin: true}, true
+	}
+
+	guildID, ok := tokenGuild(token)
+	if !ok {
+		return access{}, false
+	}
+	hash := s.ctrl.TokenHash(guildID)
+	if hash == "" || !equalSecret(HashToken(token), hash) {
+		return access{}, false
+	}
+	return access{guildID: guildID}, true
+}
+
+func (s *Server) authenticated(next func(w http.ResponseWriter, r *http.Request, a access)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		a, ok := s.authorize(r)
+		if !ok {
+			writeError(w, Errorf(http.StatusUnauthorized, "유효한 API 토큰이 필요합니다"))
+			return
+		}
+		next(w, r, a)
+	}
+}
+
+// guild는 경로의 {guild}를 읽고 토큰이 그 길드에 접근할 수 있는지 확인합니다.
+func (s *Server) guild(next func(w http.ResponseWriter, r *http.Request, guildID snowflake.ID)) http.HandlerFunc {
+	return s.authenticated(func(w http.ResponseWriter, r *http.Request, a access) {
+		guildID, err := snowflake.Parse(r.PathValue("guild"))
+		if err != nil {
+			writeError(w, Errorf(http.StatusBadRequest, "잘못된 길드 ID입니다"))
+			return
+		}
+		if !a.allows(guildID) {
+			writeError(w, Errorf(http.StatusForbidden, "이 토큰으로는 해당 길드에 접근할 수 없습니다"))
+			return
+		}
+		next(w, r, guildID)
+	})
+}
+
+func (s *Server) cors(next http.Handler) http.Handler {
+	if s.cfg.AllowOrigin == "" {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := w.Header()
+		h.Set("Access-Control-Allow-Origin", s.cfg.AllowOrigin)
+		h.Set("Access-Control-Allow-Headers", "Authorization, Content-Type")
+		h.Set("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
+		h.Add("Vary", "Origin")
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handlePlayers(w http.ResponseWriter, _ *http.Request, a access) {
+	players := []Player{}
+	for _, p := range s.ctrl.Players() {
+		if a.allows(p.GuildID) {
+			players = append(players, p)
+		}
+	}
+	writeJSON(w, http.StatusOK, players)
+}
+
+func (s *Server) handlePlayer(w http.ResponseWriter, _ *http.Request, guildID snowflake.ID) {
+	p, ok := s.ctrl.Player(guildID)
+	if !ok {
+		writeError(w, Errorf(http.StatusNotFound, "이 길드에는 플레이어가 없습니다"))
+		return
+	}
+	writeJSON(w, http.StatusOK, p)
+}
+
+func (s *Server) handleQueue(w http.ResponseWriter, _ *http.Request, guildID snowflake.ID) {
+	p, ok := s.ctrl.Player(guildID)
+	if !ok {
+		writeJSON(w, http.StatusOK, []Track{})
+		return
+	}
+	writeJSON(w, http.StatusOK, append([]Track{}, p.Queue...))
+}
+
+func (s *Server) handleEnqueue(w http.ResponseWriter, r *http.Request, guildID snowflake.ID) {
+	var req EnqueueRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if strings.TrimSpace(req.Query) == "" {
+		writeError(w, Errorf(http.StatusBadRequest, "query가 필요합니다"))
+		return
+	}
+	message, err := s.ctrl.Enqueue(r.Context(), guildID, req)
+	s.writeResult(w, guildID, message, err)
+}
+
+func (s *Server) handleSkip(w http.ResponseWriter, _ *http.Request, guildID snowflake.ID) {
+	message, err := s.ctrl.Skip(guildID)
+	s.writeResult(w, guildID, message, err)
+}
+
+func (s *Server) handlePause(w http.ResponseWriter, r *http.Request, guildID snowflake.ID) {
+	var req pauseRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	message := "재생을 재개합니다."
+	if req.Paused {
+		message = "일시정지했습니다."
+	}
+	s.writeResult(w, guildID, message, s.ctrl.Pause(guildID, req.Paused))
+}
+
+func (s *Server) handleSeek(w http.ResponseWriter, r *http.Request, guildID snowflake.ID) {
+	var req seekRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	position, err := s.ctrl.Seek(guildID, req.Position)
+	if err != nil {
+		s.writeResult(w, guildID, "", err)
+		return
+	}
+	s.writeResult(w, guildID, fmt.Sprintf("%s 위치로 이동했습니다.", embed.FormatDuration(position)), nil)
+}
+
+func (s *Server) handleVolume(w http.ResponseWriter, r *http.Request, guildID snowflake.ID) {
+	var req volumeRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Volume < 0 || req.Volume > 100 {
+		writeError(w, Errorf(http.StatusBadRequest, "볼륨은 0에서 100 사이여야 합니다"))
+		return
+	}
+	s.writeResult(w, guildID, fmt.Sprintf("볼륨을 %d%%로 설정했습니다.", req.Volume), s.ctrl.Volume(guildID, req.Volume))
+}
+
+func (s *Server) handleMove(w http.ResponseWriter, r *http.Request, guildID snowflake.ID) {
+	var req moveRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	track, err := s.ctrl.Move(guildID, req.From, req.To)
+	if err != nil {
+		s.writeResult(w, guildID, "", err)
+		return
+	}
+	s.writeResult(w, guildID, fmt.Sprintf("%s을(를) %d번에서 %d번으로 이동했습니다.", track.Info.Title, req.From, req.To), nil)
+}
+
+func (s *Server) handleRemove(w http.ResponseWriter, r *http.Request, guildID snowflake.ID) {
+	position, err := strconv.Atoi(r.PathValue("position"))
+	if err != nil {
+		writeError(w, Errorf(http.StatusBadRequest, "잘못된 위치입니다"))
+		return
+	}
+	track, err := s.ctrl.Remove(guildID, position)
+	if err != nil {
+		s.writeResult(w, guildID, "", err)
+		return
+	}
+	s.writeResult(w, guildID, fmt.Sprintf("%s을(를) 대기열에서 삭제했습니다.", track.Info.Title), nil)
+}
+
+// writeResult는 조작이 성공하면 안내 문구와 바뀐 플레이어 상태를 응답합니다.
+func (s *Server) writeResult(w http.ResponseWriter, guildID snowflake.ID, message string, err error) {
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	p, _ := s.ctrl.Player(guildID)
+	writeJSON(w, http.StatusOK, Result{Message: message, Player: p})
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)).Decode(v); err != nil {
+		writeError(w, Errorf(http.StatusBadRequest, "잘못된 요청 본문입니다: %s", err))
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Debug("API 응답 전송 실패", "error", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		status = apiErr.Status
+	}
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
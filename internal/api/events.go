@@ -0,0 +1,256 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// EventVersion은 이벤트 JSON 형식 버전입니다. 필드를 빼거나 의미를 바꾸면 올립니다.
+// 필드 추가는 하위 호환으로 보고 버전을 올리지 않습니다.
+const EventVersion = 1
+
+const (
+	// EventPlayerState는 구독 직후 한 번 보내는 길드별 현재 상태입니다 (Player).
+	EventPlayerState     = "player_state"
+	EventTrackStart      = "track_start"
+	EventTrackEnd        = "track_end"
+	EventTrackException  = "track_exception"
+	EventTrackStuck      = "track_stuck"
+	EventQueueUpdate     = "queue_update"
+	EventVolumeUpdate    = "volume_update"
+	EventRepeatUpdate    = "repeat_update"
+	EventQueueModeUpdate = "queue_mode_update"
+	EventPauseUpdate     = "pause_update"
+	EventIdle            = "idle"
+	EventDisconnect      = "disconnect"
+)
+
+// Event는 스트림으로 보내는 이벤트입니다. Data의 형식은 Type에 따라 정해집니다.
+type Event struct {
+	Version int          `json:"version"`
+	ID      uint64       `json:"id,omitempty"`
+	Type    string       `json:"type"`
+	GuildID snowflake.ID `json:"guild_id"`
+	Time    time.Time    `json:"time"`
+	Data    any          `json:"data"`
+}
+
+type TrackEventData struct {
+	Track Track `json:"track"`
+}
+
+type TrackEndData struct {
+	Track Track `json:"track"`
+	// Reason은 Lavalink의 종료 사유입니다 (finished, loadFailed, stopped, replaced, cleanup).
+	Reason string `json:"reason"`
+}
+
+type TrackExceptionData struct {
+	Track    Track  `json:"track"`
+	Message  string `json:"message"`
+	Severity string `json:"severity"`
+	Cause    string `json:"cause"`
+}
+
+type TrackStuckData struct {
+	Track     Track `json:"track"`
+	Threshold int64 `json:"threshold_ms"`
+}
+
+type QueueData struct {
+	Queue []Track `json:"queue"`
+}
+
+type VolumeData struct {
+	Volume int `json:"volume"`
+}
+
+type RepeatData struct {
+	Repeat string `json:"repeat"`
+}
+
+type QueueModeData struct {
+	QueueMode string `json:"queue_mode"`
+}
+
+type PauseData struct {
+	Paused bool `json:"paused"`
+}
+
+type IdleData struct {
+	// Timeout은 자동 퇴장까지 남은 시간이며, Leave가 false면 퇴장하지 않습니다.
+	Timeout int64 `json:"timeout_ms"`
+	Leave   bool  `json:"leave"`
+}
+
+// subscriberBuffer만큼 이벤트가 밀린 구독자는 연결을 끊습니다. 클라이언트는 다시 연결하면 됩니다.
+const subscriberBuffer = 64
+
+// heartbeatInterval마다 프록시가 연결을 끊지 않도록 주석 줄을 보냅니다.
+const heartbeatInterval = 25 * time.Second
+
+type subscriber struct {
+	guilds []snowflake.ID // 비어 있으면 모든 길드
+	ch     chan Event
+}
+
+func (s *subscriber) wants(guildID snowflake.ID) bool {
+	return len(s.guilds) == 0 || slices.Contains(s.guilds, guildID)
+}
+
+// Hub는 봇에서 발생한 이벤트를 구독자에게 나눠 줍니다. 구독자가 없으면 이벤트를 버립니다.
+type Hub struct {
+	mu     sync.Mutex
+	subs   map[*subscriber]struct{}
+	nextID atomic.Uint64
+}
+
+func NewHub() *Hub {
+	return &Hub{subs: make(map[*subscriber]struct{})}
+}
+
+// Publish는 이벤트를 구독자에게 보냅니다. 봇 이벤트 처리를 막지 않도록 기다리지 않습니다.
+func (h *Hub) Publish(guildID snowflake.ID, eventType string, data any) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.subs) == 0 {
+		return
+	}
+
+	e := Event{
+		Version: EventVersion,
+		ID:      h.nextID.Add(1),
+		Type:    eventType,
+		GuildID: guildID,
+		Time:    time.Now().UTC(),
+		Data:    data,
+	}
+	for s := range h.subs {
+		if !s.wants(guildID) {
+			continue
+		}
+		select {
+		case s.ch <- e:
+		default:
+			delete(h.subs, s)
+			close(s.ch)
+		}
+	}
+}
+
+func (h *Hub) subscribe(guilds []snowflake.ID) *subscriber {
+	s := &subscriber{guilds: guilds, ch: make(chan Event, subscriberBuffer)}
+	h.mu.Lock()
+	h.subs[s] = struct{}{}
+	h.mu.Unlock()
+	return s
+}
+
+func (h *Hub) unsubscribe(s *subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.subs[s]; ok {
+		delete(h.subs, s)
+		close(s.ch)
+	}
+}
+
+// handleEvents는 Server-Sent Events 스트림입니다.
+// ?guild=<ID>(여러 번 또는 쉼표로 구분)로 받을 길드를 고르며, 길드 토큰은 자기 길드만 받을 수 있습니다.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request, a access) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, Errorf(http.StatusInternalServerError, "스트리밍을 지원하지 않습니다"))
+		return
+	}
+
+	var guilds []snowflake.ID
+	for _, value := range r.URL.Query()["guild"] {
+		for _, part := range strings.Split(value, ",") {
+			guildID, err := snowflake.Parse(strings.TrimSpace(part))
+			if err != nil {
+				writeError(w, Errorf(http.StatusBadRequest, "잘못된 길드 ID입니다: %s", part))
+				return
+			}
+			if !a.allows(guildID) {
+				writeError(w, Errorf(http.StatusForbidden, "이 토큰으로는 해당 길드에 접근할 수 없습니다"))
+				return
+			}
+			guilds = append(guilds, guildID)
+		}
+	}
+	if len(guilds) == 0 && !a.admin {
+		guilds = []snowflake.ID{a.guildID}
+	}
+
+	sub := s.hub.subscribe(guilds)
+	defer s.hub.unsubscribe(sub)
+
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	// nginx 등 리버스 프록시가 스트림을 버퍼링하지 않도록 함
+	h.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	// 구독 직후 현재 상태를 보내 클라이언트가 따로 조회하지 않아도 되게 함
+	for _, p := range s.ctrl.Players() {
+		if sub.wants(p.GuildID) {
+			if full, ok := s.ctrl.Player(p.GuildID); ok {
+				p = full
+			}
+			if writeEvent(w, Event{Version: EventVersion, Type: EventPlayerState, GuildID: p.GuildID, Time: time.Now().UTC(), Data: p}) != nil {
+				return
+			}
+		}
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-s.closing:
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case e, ok := <-sub.ch:
+			if !ok {
+				// 이벤트를 제때 받지 못해 구독이 끊김
+				return
+			}
+			if writeEvent(w, e) != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, e Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if e.ID != 0 {
+		if _, err := fmt.Fprintf(w, "id: %d\n", e.ID); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, data)
+	return err
+}
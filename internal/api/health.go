@@ -0,0 +1,106 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Health는 /healthz, /readyz 응답입니다. 두 경로는 같은 내용을 보내고 상태 코드만 다릅니다.
+type Health struct {
+	// Live가 false면 봇이 스스로 회복하지 못한 상태라 재시작이 필요합니다 (/healthz 503).
+	Live bool `json:"live"`
+	// Ready가 false면 지금은 명령어를 처리할 수 없습니다 (/readyz 503).
+	Ready              bool          `json:"ready"`
+	Gateway            GatewayHealth `json:"gateway"`
+	Nodes              []NodeHealth  `json:"lavalink_nodes"`
+	CommandsRegistered bool          `json:"commands_registered"`
+	ShuttingDown       bool          `json:"shutting_down,omitempty"`
+}
+
+type GatewayHealth struct {
+	// Status는 disgo 게이트웨이 상태입니다 (Ready, Resuming, Disconnected 등).
+	Status  string `json:"status"`
+	Latency int64  `json:"latency_ms"`
+	// DownFor는 Ready가 아닌 상태가 이어진 시간입니다.
+	DownFor int64 `json:"down_for_ms,omitempty"`
+}
+
+type NodeHealth struct {
+	Name string `json:"name"`
+	// Status는 disgolink 노드 상태입니다 (CONNECTED, RECONNECTING 등).
+	Status  string `json:"status"`
+	Players int    `json:"players"`
+}
+
+// HealthServer는 API 서버와 별도로 여는 헬스 체크(/healthz, /readyz) 전용 서버입니다.
+// API_ADDR 없이도 컨테이너 프로브가 쓸 수 있도록 HEALTH_ADDR을 설정했을 때만 엽니다.
+type HealthServer struct {
+	addr string
+	srv  *http.Server
+}
+
+func NewHealthServer(addr string, health func() Health) *HealthServer {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", healthzHandler(health))
+	mux.HandleFunc("GET /readyz", readyzHandler(health))
+	return &HealthServer{
+		addr: addr,
+		srv: &http.Server{
+			Addr:              addr,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+	}
+}
+
+// Start는 주소를 바로 열어 포트 충돌 등을 반환하고, 요청 처리는 백그라운드에서 합니다.
+func (s *HealthServer) Start() error {
+	ln, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := s.srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("헬스 체크 서버 오류", "error", err)
+		}
+	}()
+	slog.Info("헬스 체크 서버 시작", "addr", ln.Addr().String())
+	return nil
+}
+
+func (s *HealthServer) Shutdown(ctx context.Context) error {
+	return s.srv.Shutdown(ctx)
+}
+
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	healthzHandler(s.ctrl.Health)(w, r)
+}
+
+func (s *Server) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	readyzHandler(s.ctrl.Health)(w, r)
+}
+
+func healthzHandler(health func() Health) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		h := health()
+		writeJSON(w, healthStatus(h.Live), h)
+	}
+}
+
+func readyzHandler(health func() Health) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		h := health()
+		writeJSON(w, healthStatus(h.Ready), h)
+	}
+}
+
+func healthStatus(ok bool) int {
+	if ok {
+		return http.StatusOK
+	}
+	return http.StatusServiceUnavailable
+}
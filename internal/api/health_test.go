@@ -0,0 +1,41 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHealthServer(t *testing.T) {
+	health := Health{Live: true, Ready: false, Gateway: GatewayHealth{Status: "Ready"}}
+	s := NewHealthServer("127.0.0.1:0", func() Health { return health })
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{path: "/healthz", status: http.StatusOK},
+		{path: "/readyz", status: http.StatusServiceUnavailable},
+		// 메트릭과 API 경로는 헬스 체크 서버에 없음
+		{path: "/metrics", status: http.StatusNotFound},
+		{path: "/api/v1/players", status: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		s.srv.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if rec.Code != tt.status {
+			t.Errorf("GET %s = %d, want %d", tt.path, rec.Code, tt.status)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	s.srv.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	var got Health
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("응답 JSON: %v", err)
+	}
+	if !got.Live || got.Ready || got.Gateway.Status != "Ready" {
+		t.Errorf("응답 = %+v, want %+v", got, health)
+	}
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// NewToken은 길드용 API 토큰을 만들고 저장할 해시를 함께 반환합니다.
+// 토큰은 "<길드 ID>.<임의 문자열>" 형식이라 요청만으로 어느 길드의 토큰인지 알 수 있습니다.
+func NewToken(guildID snowflake.ID) (token, hash string, err error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", err
+	}
+	token = guildID.String() + "." + base64.RawURLEncoding.EncodeToString(secret)
+	return token, HashToken(token), nil
+}
+
+// HashToken은 설정에 저장하는 토큰 해시입니다. 토큰 원문은 저장하지 않습니다.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// tokenGuild는 토큰에 적힌 길드 ID를 읽습니다.
+func tokenGuild(token string) (snowflake.ID, bool) {
+	prefix, _, ok := strings.Cut(token, ".")
+	if !ok {
+		return 0, false
+	}
+	guildID, err := snowflake.Parse(prefix)
+	return guildID, err == nil
+}
+
+func equalSecret(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
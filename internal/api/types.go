@@ -0,0 +1,144 @@
+package api
+
+import (
+	"github.com/disgoorg/disgolink/v3/disgolink"
+	"github.com/disgoorg/disgolink/v3/lavalink"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/uzih05/discord-music-bot/internal/player"
+)
+
+// Track은 API 응답에 쓰는 곡 정보입니다.
+type Track struct {
+	Title       string       `json:"title"`
+	Author      string       `json:"author"`
+	URI         string       `json:"uri,omitempty"`
+	ArtworkURL  string       `json:"artwork_url,omitempty"`
+	Source      string       `json:"source"`
+	Duration    int64        `json:"duration_ms"`
+	Stream      bool         `json:"stream"`
+	RequesterID snowflake.ID `json:"requester_id,omitempty"`
+	Autoplay    bool         `json:"autoplay,omitempty"`
+}
+
+func NewTrack(track lavalink.Track) Track {
+	data := player.Data(track)
+	t := Track{
+		Title:       track.Info.Title,
+		Author:      track.Info.Author,
+		Source:      track.Info.SourceName,
+		Duration:    int64(track.Info.Length),
+		Stream:      track.Info.IsStream,
+		RequesterID: data.RequesterID,
+		Autoplay:    data.Autoplay,
+	}
+	if track.Info.URI != nil {
+		t.URI = *track.Info.URI
+	}
+	if track.Info.ArtworkURL != nil {
+		t.ArtworkURL = *track.Info.ArtworkURL
+	}
+	return t
+}
+
+func NewTracks(tracks []lavalink.Track) []Track {
+	result := make([]Track, len(tracks))
+	for i, track := range tracks {
+		result[i] = NewTrack(track)
+	}
+	return result
+}
+
+// Player는 길드 플레이어 상태입니다. Queue는 상세 조회에서만 채웁니다.
+type Player struct {
+	GuildID     snowflake.ID `json:"guild_id"`
+	Connected   bool         `json:"connected"`
+	Paused      bool         `json:"paused"`
+	Volume      int          `json:"volume"`
+	Repeat      string       `json:"repeat"`
+	QueueMode   string       `json:"queue_mode"`
+	Position    int64        `json:"position_ms"`
+	Current     *Track       `json:"current"`
+	QueueLength int          `json:"queue_length"`
+	Queue       []Track      `json:"queue,omitempty"`
+}
+
+// NewPlayer는 길드 플레이어 상태를 만듭니다. p는 Lavalink 플레이어가 없으면 nil입니다.
+func NewPlayer(gp *player.GuildPlayer, p disgolink.Player, withQueue bool) Player {
+	gp.Mu.Lock()
+	defer gp.Mu.Unlock()
+
+	state := Player{
+		GuildID:     gp.GuildID,
+		Volume:      gp.Volume,
+		Repeat:      RepeatName(gp.Repeat),
+		QueueMode:   QueueModeName(gp.QueueMode),
+		QueueLength: len(gp.Queue),
+	}
+	if gp.CurrentTrack != nil {
+		current := NewTrack(*gp.CurrentTrack)
+		state.Current = &current
+	}
+	if withQueue {
+		state.Queue = NewTracks(gp.Queue)
+	}
+	if p != nil {
+		state.Connected = true
+		state.Paused = p.Paused()
+		if p.Track() != nil {
+			state.Position = int64(p.Position())
+		}
+	}
+	return state
+}
+
+func RepeatName(mode player.RepeatMode) string {
+	switch mode {
+	case player.RepeatOne:
+		return "one"
+	case player.RepeatAll:
+		return "all"
+	default:
+		return "off"
+	}
+}
+
+func QueueModeName(mode player.QueueMode) string {
+	if mode == player.QueueFair {
+		return "fair"
+	}
+	return "fifo"
+}
+
+type EnqueueRequest struct {
+	Query string `json:"query"`
+	// Source는 URL이 아닌 검색어에 쓸 검색 소스입니다. 비우면 길드 기본 소스를 씁니다.
+	Source string `json:"source,omitempty"`
+	// UserID는 신청자로 표시할 사용자입니다. 봇이 음성 채널에 없으면 이 사용자의 음성 채널에 접속합니다.
+	UserID snowflake.ID `json:"user_id,omitempty"`
+	// VoiceChannelID를 지정하면 봇이 음성 채널에 없을 때 이 채널에 접속합니다.
+	VoiceChannelID snowflake.ID `json:"voice_channel_id,omitempty"`
+}
+
+type pauseRequest struct {
+	Paused bool `json:"paused"`
+}
+
+type seekRequest struct {
+	// Position은 /seek과 같은 형식입니다 (1:23, 90s, +30, -15).
+	Position string `json:"position"`
+}
+
+type volumeRequest struct {
+	Volume int `json:"volume"`
+}
+
+type moveRequest struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+}
+
+// Result는 조작 API의 응답입니다. Message는 슬래시 명령어와 같은 안내 문구입니다.
+type Result struct {
+	Message string `json:"message"`
+	Player  Player `json:"player"`
+}
@@ -0,0 +1,237 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgolink/v3/disgolink"
+	"github.com/disgoorg/disgolink/v3/lavalink"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/uzih05/discord-music-bot/internal/api"
+	"github.com/uzih05/discord-music-bot/internal/player"
+	"github.com/uzih05/discord-music-bot/internal/settings"
+)
+
+// newAPIServer는 API_ADDR이 설정되어 있을 때만 HTTP API 서버를 만듭니다.
+func newAPIServer(b *Bot) *api.Server {
+	addr := os.Getenv("API_ADDR")
+	if addr == "" {
+		return nil
+	}
+	s := api.New(api.Config{
+		Addr:        addr,
+		AdminToken:  os.Getenv("API_ADMIN_TOKEN"),
+		AllowOrigin: os.Getenv("API_ALLOW_ORIGIN"),
+	}, &apiController{bot: b}, b.Events)
+	// 메트릭은 봇 전체의 상태를 보여주므로 길드 토큰이 아닌 관리용 토큰으로만 염
+	s.HandleAdmin("GET /metrics", b.metrics.registry.Handler())
+	return s
+}
+
+func (b *Bot) handleAPICommand(event *events.ApplicationCommandInteractionCreate) {
+	data := event.SlashCommandInteractionData()
+	guildID := *event.GuildID()
+	if data.SubCommandName == nil {
+		return
+	}
+
+	switch *data.SubCommandName {
+	case "token":
+		token, hash, err := api.NewToken(guildID)
+		if err != nil {
+			b.respondEphemeral(event, "토큰 발급 실패: "+err.Error())
+			return
+		}
+		if _, err := b.Settings.Update(guildID, func(g *settings.Guild) {
+			g.APITokenHash = hash
+		}); err != nil {
+			b.respondEphemeral(event, "토큰 저장 실패: "+err.Error())
+			return
+		}
+		content := fmt.Sprintf("새 API 토큰을 발급했습니다. 이 메시지를 닫으면 다시 볼 수 없으니 안전한 곳에 보관하세요.\n```\n%s\n```\n요청 헤더에 `Authorization: Bearer <토큰>`으로 넣어 사용합니다. 이전 토큰은 더 이상 쓸 수 없습니다.", token)
+		if b.API == nil {
+			content += "\n⚠️ 봇에 `API_ADDR`이 설정되어 있지 않아 지금은 API 서버가 꺼져 있습니다."
+		}
+		b.respondEphemeral(event, content)
+
+	case "revoke":
+		if _, err := b.Settings.Update(guildID, func(g *settings.Guild) {
+			g.APITokenHash = ""
+		}); err != nil {
+			b.respondEphemeral(event, "토큰 저장 실패: "+err.Error())
+			return
+		}
+		b.respondEphemeral(event, "API 토큰을 폐기했습니다.")
+	}
+}
+
+// apiController는 HTTP API 요청을 슬래시 명령어와 같은 재생 로직으로 처리합니다.
+type apiController struct {
+	bot *Bot
+}
+
+var _ api.Controller = (*apiController)(nil)
+
+func (c *apiController) TokenHash(guildID snowflake.ID) string {
+	return c.bot.Settings.Get(guildID).APITokenHash
+}
+
+func (c *apiController) Players() []api.Player {
+	c.bot.mu.Lock()
+	players := make([]*player.GuildPlayer, 0, len(c.bot.Players))
+	for _, gp := range c.bot.Players {
+		players = append(players, gp)
+	}
+	c.bot.mu.Unlock()
+
+	result := make([]api.Player, len(players))
+	for i, gp := range players {
+		result[i] = api.NewPlayer(gp, c.bot.Lavalink.ExistingPlayer(gp.GuildID), false)
+	}
+	return result
+}
+
+func (c *apiController) Player(guildID snowflake.ID) (api.Player, bool) {
+	c.bot.mu.Lock()
+	gp, ok := c.bot.Players[guildID]
+	c.bot.mu.Unlock()
+	if !ok {
+		return api.Player{}, false
+	}
+	return api.NewPlayer(gp, c.bot.Lavalink.ExistingPlayer(guildID), true), true
+}
+
+func (c *apiController) Health() api.Health {
+	return c.bot.health()
+}
+
+// Enqueue는 /play처럼 곡을 찾아 재생하거나 대기열에 추가합니다.
+// 검색어는 결과 목록 대신 첫 번째 결과를 바로 추가합니다 (음악 신청 채널과 같음).
+func (c *apiController) Enqueue(ctx context.Context, guildID snowflake.ID, req api.EnqueueRequest) (string, error) {
+	b := c.bot
+	gp := b.GetOrCreatePlayer(guildID)
+
+	if _, ok := b.Client.Caches().VoiceState(guildID, b.Client.ApplicationID()); !ok {
+		channelID := req.VoiceChannelID
+		if channelID == 0 && req.UserID != 0 {
+			if vs, ok := b.Client.Caches().VoiceState(guildID, req.UserID); ok && vs.ChannelID != nil {
+				channelID = *vs.ChannelID
+			}
+		}
+		if channelID == 0 {
+			return "", api.Errorf(http.StatusConflict, "봇이 음성 채널에 없습니다. voice_channel_id나 음성 채널에 있는 user_id를 지정해주세요")
+		}
+
+		gp.Mu.Lock()
+		textChannelID := gp.TextChannelID
+		gp.Mu.Unlock()
+		if _, err := b.connect(guildID, textChannelID, &channelID); err != nil {
+			return "", fmt.Errorf("음성 채널 연결 실패: %w", err)
+		}
+	}
+
+	node := b.bestNode(guildID, "")
+	if node == nil {
+		return "", api.Errorf(http.StatusServiceUnavailable, "사용 가능한 Lavalink 노드가 없습니다")
+	}
+
+	searchQuery := req.Query
+	if !urlPattern.MatchString(req.Query) {
+		searchQuery = b.searchSource(guildID, req.Source).Query(req.Query)
+	}
+
+	var (
+		message string
+		loadErr error
+	)
+	respond := func(content string) {
+		message = content
+	}
+	b.loadTracks(ctx, node, searchQuery, disgolink.NewResultHandler(
+		func(track lavalink.Track) {
+			b.playOrQueue(gp, player.WithRequester(track, req.UserID), respond)
+		},
+		func(playlist lavalink.Playlist) {
+			b.handlePlaylist(gp, playlist, req.UserID, respond)
+		},
+		func(tracks []lavalink.Track) {
+			if len(tracks) == 0 {
+				loadErr = api.Errorf(http.StatusNotFound, "검색 결과가 없습니다")
+				return
+			}
+			b.playOrQueue(gp, player.WithRequester(tracks[0], req.UserID), respond)
+		},
+		func() {
+			loadErr = api.Errorf(http.StatusNotFound, "검색 결과가 없습니다")
+		},
+		func(err error) {
+			slog.Error("트랙 로딩 실패", "error", err)
+			loadErr = api.Errorf(http.StatusBadGateway, "트랙 로딩 실패: %s", err)
+		},
+	))
+	return message, loadErr
+}
+
+func (c *apiController) Skip(guildID snowflake.ID) (string, error) {
+	p := c.bot.Lavalink.ExistingPlayer(guildID)
+	if p == nil {
+		return "", apiError(errNothingPlaying)
+	}
+	return c.bot.skipTrack(p, c.bot.GetOrCreatePlayer(guildID))
+}
+
+func (c *apiController) Pause(guildID snowflake.ID, paused bool) error {
+	return apiError(c.bot.setPaused(guildID, paused))
+}
+
+func (c *apiController) Seek(guildID snowflake.ID, position string) (lavalink.Duration, error) {
+	target, err := c.bot.seekTo(guildID, position)
+	if err != nil {
+		return 0, apiError(err)
+	}
+	c.bot.updateNowPlayingEmbed(guildID)
+	return target, nil
+}
+
+func (c *apiController) Volume(guildID snowflake.ID, volume int) error {
+	if err := c.bot.setVolume(guildID, volume); err != nil {
+		return apiError(err)
+	}
+	c.bot.updateNowPlayingEmbed(guildID)
+	return nil
+}
+
+func (c *apiController) Move(guildID snowflake.ID, from, to int) (lavalink.Track, error) {
+	track, ok := c.bot.GetOrCreatePlayer(guildID).Move(from, to)
+	if !ok {
+		return lavalink.Track{}, api.Errorf(http.StatusBadRequest, "잘못된 위치입니다")
+	}
+	return track, nil
+}
+
+func (c *apiController) Remove(guildID snowflake.ID, position int) (lavalink.Track, error) {
+	track, ok := c.bot.GetOrCreatePlayer(guildID).Remove(position)
+	if !ok {
+		return lavalink.Track{}, api.Errorf(http.StatusNotFound, "잘못된 위치입니다")
+	}
+	return track, nil
+}
+
+// apiError는 재생 로직의 오류를 알맞은 HTTP 상태 코드로 바꿉니다.
+func apiError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, errNothingPlaying):
+		return api.Errorf(http.StatusConflict, "%s", err)
+	case errors.Is(err, player.ErrInvalidTime), errors.Is(err, player.ErrNotSeekable), errors.Is(err, player.ErrSeekOutOfSpan):
+		return api.Errorf(http.StatusBadRequest, "%s", err)
+	default:
+		return err
+	}
+}
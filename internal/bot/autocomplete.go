@@ -0,0 +1,111 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgolink/v3/disgolink"
+	"github.com/disgoorg/disgolink/v3/lavalink"
+	"github.com/uzih05/discord-music-bot/internal/embed"
+	"github.com/uzih05/discord-music-bot/internal/search"
+)
+
+const (
+	// suggestMinLength보다 짧은 검색어는 자동완성하지 않습니다.
+	suggestMinLength = 2
+	// Discord는 자동완성 응답을 3초 안에 받아야 하므로, 디바운스와 응답 전송 시간을 빼고 검색 시간을 제한합니다.
+	suggestTimeout = 1500 * time.Millisecond
+)
+
+func (b *Bot) onAutocomplete(event *events.AutocompleteInteractionCreate) {
+	if event.Data.CommandName != "play" || event.Data.Focused().Name != "query" {
+		_ = event.AutocompleteResult(suggestChoices(nil))
+		return
+	}
+	// 이벤트는 게이트웨이 고루틴에서 순서대로 처리되므로, 디바운스 대기가 다른 이벤트를 막지 않도록 분리
+	go b.handlePlayAutocomplete(event)
+}
+
+// handlePlayAutocomplete는 /play 검색어를 Lavalink로 검색해 최대 25곡을 제안합니다.
+// 제안의 값은 트랙 URI이므로 선택하면 handlePlay가 검색 결과 목록 없이 바로 재생합니다.
+func (b *Bot) handlePlayAutocomplete(event *events.AutocompleteInteractionCreate) {
+	query := strings.TrimSpace(event.Data.String("query"))
+	if utf8.RuneCountInString(query) < suggestMinLength || urlPattern.MatchString(query) {
+		_ = event.AutocompleteResult(suggestChoices(nil))
+		return
+	}
+
+	source := b.searchSource(*event.GuildID(), event.Data.String("source"))
+	key := search.SuggestKey(source, query)
+
+	if tracks, ok := b.Suggestions.Get(key); ok {
+		_ = event.AutocompleteResult(suggestChoices(tracks))
+		return
+	}
+	if !<-b.Suggestions.Debounce(event.User().ID) {
+		// 더 최근 입력이 있으므로 검색하지 않고 이전 결과만 보여줌
+		_ = event.AutocompleteResult(suggestChoices(b.Suggestions.Closest(key)))
+		return
+	}
+
+	node := b.bestNode(*event.GuildID(), "")
+	if node == nil {
+		_ = event.AutocompleteResult(suggestChoices(nil))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), suggestTimeout)
+	defer cancel()
+
+	var tracks []lavalink.Track
+	b.loadTracks(ctx, node, source.Query(query), disgolink.NewResultHandler(
+		func(track lavalink.Track) { tracks = []lavalink.Track{track} },
+		func(playlist lavalink.Playlist) { tracks = playlist.Tracks },
+		func(result []lavalink.Track) { tracks = result },
+		func() {},
+		func(err error) {
+			slog.Debug("자동완성 검색 실패", "query", query, "error", err)
+		},
+	))
+
+	b.Suggestions.Set(key, tracks)
+	_ = event.AutocompleteResult(suggestChoices(tracks))
+}
+
+func suggestChoices(tracks []lavalink.Track) []discord.AutocompleteChoice {
+	choices := make([]discord.AutocompleteChoice, 0, 25)
+	for _, track := range tracks {
+		if len(choices) == 25 {
+			break
+		}
+		// 선택값은 100자 제한이 있으므로 URI가 너무 길면 제외
+		if track.Info.URI == nil || len(*track.Info.URI) > 100 {
+			continue
+		}
+
+		duration := embed.FormatDuration(track.Info.Length)
+		if track.Info.IsStream {
+			duration = "LIVE"
+		}
+		suffix := fmt.Sprintf(" (%s)", duration)
+		name := track.Info.Title
+		if track.Info.Author != "" {
+			name += " - " + track.Info.Author
+		}
+		if r := []rune(name); len(r)+utf8.RuneCountInString(suffix) > 100 {
+			name = string(r[:100-utf8.RuneCountInString(suffix)-1]) + "…"
+		}
+
+		choices = append(choices, discord.AutocompleteChoiceString{
+			Name:  name + suffix,
+			Value: *track.Info.URI,
+		})
+	}
+	return choices
+}
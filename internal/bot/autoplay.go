@@ -0,0 +1,120 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgolink/v3/disgolink"
+	"github.com/disgoorg/disgolink/v3/lavalink"
+	"github.com/uzih05/discord-music-bot/internal/player"
+	"github.com/uzih05/discord-music-bot/internal/settings"
+)
+
+// autoplaySeeds는 관련 곡을 찾을 때 참고할 최근 재생 기록 수입니다.
+const autoplaySeeds = 3
+
+var errNoAutoplayTrack = errors.New("자동 재생할 곡을 찾지 못했습니다")
+
+// autoplayQueries는 seed와 비슷한 곡을 찾기 위한 Lavalink 검색어입니다.
+// YouTube 곡은 믹스(라디오) 재생목록을 먼저 시도하고, 그 외에는 아티스트 이름으로 검색합니다.
+func autoplayQueries(seed lavalink.Track) []string {
+	var queries []string
+	if seed.Info.SourceName == "youtube" && seed.Info.Identifier != "" {
+		queries = append(queries, fmt.Sprintf("https://www.youtube.com/watch?v=%s&list=RD%s", seed.Info.Identifier, seed.Info.Identifier))
+	}
+	if seed.Info.Author != "" {
+		queries = append(queries, lavalink.SearchTypeYouTube.Apply(seed.Info.Author))
+	}
+	return queries
+}
+
+func normalizeTitle(title string) string {
+	return strings.ToLower(strings.Join(strings.Fields(title), " "))
+}
+
+// autoplay는 최근 재생 기록을 바탕으로 아직 재생하지 않은 관련 곡을 골라 바로 재생합니다.
+func (b *Bot) autoplay(ctx context.Context, p disgolink.Player, gp *player.GuildPlayer) (*lavalink.Track, error) {
+	history := gp.HistoryList()
+	if len(history) == 0 {
+		return nil, errNoAutoplayTrack
+	}
+
+	playedIDs := make(map[string]struct{}, len(history))
+	playedTitles := make(map[string]struct{}, len(history))
+	for _, track := range history {
+		playedIDs[track.Info.Identifier] = struct{}{}
+		playedTitles[normalizeTitle(track.Info.Title)] = struct{}{}
+	}
+	g := b.Settings.Get(gp.GuildID)
+	fresh := func(track lavalink.Track) bool {
+		if track.Info.IsStream || tooLong(g, track) {
+			return false
+		}
+		if _, ok := playedIDs[track.Info.Identifier]; ok {
+			return false
+		}
+		_, ok := playedTitles[normalizeTitle(track.Info.Title)]
+		return !ok
+	}
+
+	for _, seed := range history[:min(len(history), autoplaySeeds)] {
+		for _, query := range autoplayQueries(seed) {
+			var candidates []lavalink.Track
+			b.loadTracks(ctx, p.Node(), query, disgolink.NewResultHandler(
+				func(track lavalink.Track) { candidates = []lavalink.Track{track} },
+				func(playlist lavalink.Playlist) { candidates = playlist.Tracks },
+				func(tracks []lavalink.Track) { candidates = tracks },
+				func() {},
+				func(err error) {
+					slog.Debug("자동 재생 검색 실패", "guild", gp.GuildID, "query", query, "error", err)
+				},
+			))
+
+			for _, candidate := range candidates {
+				if !fresh(candidate) {
+					continue
+				}
+				track := player.WithAutoplay(candidate)
+				gp.SetCurrentTrack(&track)
+				if err := p.Update(ctx,
+					lavalink.WithTrack(track),
+					lavalink.WithFilters(gp.FilterState().Build()),
+				); err != nil {
+					gp.SetCurrentTrack(nil)
+					return nil, err
+				}
+				slog.Info("자동 재생", "guild", gp.GuildID, "seed", seed.Info.Title, "track", track.Info.Title)
+				return &track, nil
+			}
+		}
+	}
+	return nil, errNoAutoplayTrack
+}
+
+// skipToAutoplay는 스킵으로 대기열이 비었을 때 자동 재생 곡으로 넘어가며, 찾지 못하면 재생을 멈춥니다.
+func (b *Bot) skipToAutoplay(p disgolink.Player, gp *player.GuildPlayer) {
+	ctx := context.TODO()
+	if _, err := b.autoplay(ctx, p, gp); err != nil {
+		slog.Warn("자동 재생 실패", "guild", gp.GuildID, "error", err)
+		_ = p.Update(ctx, lavalink.WithNullTrack())
+	}
+}
+
+func (b *Bot) handleAutoplay(event *events.ApplicationCommandInteractionCreate) {
+	enabled := event.SlashCommandInteractionData().Bool("enabled")
+	if _, err := b.Settings.Update(*event.GuildID(), func(g *settings.Guild) {
+		g.Autoplay = enabled
+	}); err != nil {
+		b.respondEphemeral(event, "설정 저장 실패: "+err.Error())
+		return
+	}
+	if enabled {
+		b.respondEphemeral(event, "자동 재생을 켰습니다. 대기열이 비면 최근에 들은 곡과 비슷한 곡을 이어서 재생합니다.")
+		return
+	}
+	b.respondEphemeral(event, "자동 재생을 껐습니다.")
+}
@@ -5,16 +5,24 @@ import (
 	"log/slog"
 	"os"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/disgoorg/disgo"
 	"github.com/disgoorg/disgo/bot"
 	"github.com/disgoorg/disgo/cache"
 	"github.com/disgoorg/disgo/gateway"
 	"github.com/disgoorg/disgolink/v3/disgolink"
+	"github.com/disgoorg/disgolink/v3/lavalink"
 	"github.com/disgoorg/snowflake/v2"
+	"github.com/uzih05/discord-music-bot/internal/api"
 	"github.com/uzih05/discord-music-bot/internal/command"
+	"github.com/uzih05/discord-music-bot/internal/lyrics"
 	"github.com/uzih05/discord-music-bot/internal/player"
+	"github.com/uzih05/discord-music-bot/internal/playlist"
 	"github.com/uzih05/discord-music-bot/internal/search"
+	"github.com/uzih05/discord-music-bot/internal/settings"
+	"github.com/uzih05/discord-music-bot/internal/store"
 )
 
 type Bot struct {
@@ -22,29 +30,90 @@ type Bot struct {
 	Lavalink    disgolink.Client
 	Players     map[snowflake.ID]*player.GuildPlayer
 	SearchCache *search.Cache
-	mu          sync.Mutex
+	Suggestions *search.SuggestCache
+	Store       store.Store
+	Settings    *settings.Manager
+	Playlists   *playlist.Manager
+	Lyrics      lyrics.Provider
+	// Events는 API 이벤트 스트림으로 보낼 재생 이벤트입니다. API가 꺼져 있으면 구독자가 없어 버려집니다.
+	Events *api.Hub
+	// API는 API_ADDR이 없으면 nil입니다.
+	API *api.Server
+	mu  sync.Mutex
+
+	// healthServer는 HEALTH_ADDR이 없으면 nil입니다.
+	healthServer *api.HealthServer
+
+	pendingRestores map[snowflake.ID]player.Snapshot
+	voice           map[snowflake.ID]lavalink.VoiceState
+	nodeRegions     map[string]string
+	migrateMu       sync.Mutex
+	bots            map[snowflake.ID]struct{}
+	lyricsViews     map[snowflake.ID]*lyricsView
+	panelTimers     map[snowflake.ID]*time.Timer
+	saveTimers      map[snowflake.ID]*time.Timer
+	saveMu          sync.Mutex
+	panelMu         sync.Mutex
+	streamStates    map[snowflake.ID]streamState
+	streamMu        sync.Mutex
+	closing         atomic.Bool
+	// requestChannels는 REQUEST_CHANNEL=true일 때만 켜지며, 신청 채널 메시지를 읽는 인텐트를 요청합니다.
+	requestChannels bool
+	metrics         *botMetrics
+
+	// commandsRegistered는 Start에서 슬래시 명령어 등록에 성공했는지입니다 (/readyz).
+	commandsRegistered atomic.Bool
+	gatewayDownSince   time.Time
+	healthMu           sync.Mutex
 }
 
 func NewBot(token string) (*Bot, error) {
+	st, err := newStore()
+	if err != nil {
+		return nil, err
+	}
+
 	b := &Bot{
-		Players:     make(map[snowflake.ID]*player.GuildPlayer),
-		SearchCache: search.NewCache(),
+		Players:         make(map[snowflake.ID]*player.GuildPlayer),
+		SearchCache:     search.NewCache(),
+		Suggestions:     search.NewSuggestCache(),
+		Store:           st,
+		Settings:        settings.NewManager(st),
+		Playlists:       playlist.NewManager(st),
+		pendingRestores: make(map[snowflake.ID]player.Snapshot),
+		voice:           make(map[snowflake.ID]lavalink.VoiceState),
+		nodeRegions:     make(map[string]string),
+		bots:            make(map[snowflake.ID]struct{}),
+		lyricsViews:     make(map[snowflake.ID]*lyricsView),
+		panelTimers:     make(map[snowflake.ID]*time.Timer),
+		saveTimers:      make(map[snowflake.ID]*time.Timer),
+		streamStates:    make(map[snowflake.ID]streamState),
+		Events:          api.NewHub(),
+		metrics:         newBotMetrics(),
+		requestChannels: os.Getenv("REQUEST_CHANNEL") == "true",
 	}
+	b.metrics.registry.OnCollect(b.collectMetrics)
+
+	b.Lyrics = newLyricsProvider(b)
+	b.API = newAPIServer(b)
+	b.healthServer = newHealthServer(b)
 
 	client, err := disgo.New(token,
+		bot.WithRestClient(newRestClient(token, b.metrics)),
 		bot.WithGatewayConfigOpts(
-			gateway.WithIntents(
-				gateway.IntentGuilds,
-				gateway.IntentGuildVoiceStates,
-			),
+			gateway.WithIntents(b.intents()...),
 		),
 		bot.WithCacheConfigOpts(
 			cache.WithCaches(cache.FlagVoiceStates),
 		),
 		bot.WithEventListenerFunc(b.onApplicationCommand),
+		bot.WithEventListenerFunc(b.onAutocomplete),
 		bot.WithEventListenerFunc(b.onComponentInteraction),
+		bot.WithEventListenerFunc(b.onModalSubmit),
 		bot.WithEventListenerFunc(b.onVoiceStateUpdate),
 		bot.WithEventListenerFunc(b.onVoiceServerUpdate),
+		bot.WithEventListenerFunc(b.onGuildReady),
+		bot.WithEventListenerFunc(b.onGuildMessageCreate),
 	)
 	if err != nil {
 		return nil, err
@@ -56,15 +125,25 @@ func NewBot(token string) (*Bot, error) {
 		disgolink.WithListenerFunc(b.onTrackEnd),
 		disgolink.WithListenerFunc(b.onTrackException),
 		disgolink.WithListenerFunc(b.onTrackStuck),
+		disgolink.WithPlugins(&nodeWatcher{bot: b}),
 	)
 
 	return b, nil
 }
 
 func (b *Bot) Start(ctx context.Context) error {
+	// 노드 연결과 명령어 등록을 기다리는 동안에도 프로브가 응답하도록 먼저 엶
+	// 프로브용 포트를 열지 못해도 봇은 계속 실행
+	if b.healthServer != nil {
+		if err := b.healthServer.Start(); err != nil {
+			slog.Error("헬스 체크 서버 시작 실패", "error", err)
+			b.healthServer = nil
+		}
+	}
 	if err := b.registerLavalinkNodes(ctx); err != nil {
 		return err
 	}
+	b.loadSnapshots()
 
 	guildID := os.Getenv("GUILD_ID")
 	if guildID != "" {
@@ -73,12 +152,15 @@ func (b *Bot) Start(ctx context.Context) error {
 			slog.Warn("GUILD_ID 파싱 실패, 글로벌 커맨드로 등록합니다", "error", err)
 			if _, err := b.Client.Rest().SetGlobalCommands(b.Client.ApplicationID(), command.Commands); err != nil {
 				slog.Error("글로벌 커맨드 등록 실패", "error", err)
+			} else {
+				b.commandsRegistered.Store(true)
 			}
 		} else {
 			if _, err := b.Client.Rest().SetGuildCommands(b.Client.ApplicationID(), id, command.Commands); err != nil {
 				slog.Error("길드 커맨드 등록 실패", "error", err)
 			} else {
 				slog.Info("길드 커맨드 등록 완료", "guild_id", id)
+				b.commandsRegistered.Store(true)
 			}
 		}
 	} else {
@@ -86,43 +168,50 @@ func (b *Bot) Start(ctx context.Context) error {
 			slog.Error("글로벌 커맨드 등록 실패", "error", err)
 		} else {
 			slog.Info("글로벌 커맨드 등록 완료")
+			b.commandsRegistered.Store(true)
 		}
 	}
 
-	return b.Client.OpenGateway(ctx)
+	if err := b.Client.OpenGateway(ctx); err != nil {
+		return err
+	}
+	if b.API != nil {
+		return b.API.Start()
+	}
+	return nil
 }
 
+// Stop은 shutdownTimeout 안에 API 서버를 닫고, 재생 상태를 저장한 뒤 메시지를 정리하고 음성 채널에서 나갑니다.
+// 시간이 지나면 남은 정리를 건너뛰고 연결을 끊습니다.
 func (b *Bot) Stop(ctx context.Context) {
-	b.Lavalink.Close()
-	b.Client.Close(ctx)
-}
+	ctx, cancel := context.WithTimeout(ctx, shutdownTimeout)
+	defer cancel()
 
-func (b *Bot) registerLavalinkNodes(ctx context.Context) error {
-	host := os.Getenv("LAVALINK_HOST")
-	if host == "" {
-		host = "localhost"
-	}
-	port := os.Getenv("LAVALINK_PORT")
-	if port == "" {
-		port = "2333"
+	b.closing.Store(true)
+	if b.API != nil {
+		if err := b.API.Shutdown(ctx); err != nil {
+			slog.Warn("API 서버 종료 실패", "error", err)
+		}
 	}
-	password := os.Getenv("LAVALINK_PASSWORD")
-	if password == "" {
-		password = "youshallnotpass"
+	b.shutdownPlayers(ctx)
+	b.Lavalink.Close()
+	b.Client.Close(ctx)
+	// 종료 중에도 /readyz가 shutting_down을 알릴 수 있도록 마지막에 닫음
+	if b.healthServer != nil {
+		if err := b.healthServer.Shutdown(ctx); err != nil {
+			slog.Warn("헬스 체크 서버 종료 실패", "error", err)
+		}
 	}
+}
 
-	node, err := b.Lavalink.AddNode(ctx, disgolink.NodeConfig{
-		Name:     "main",
-		Address:  host + ":" + port,
-		Password: password,
-		Secure:   false,
-	})
-	if err != nil {
-		return err
+// intents는 게이트웨이에 요청할 인텐트입니다.
+// Message Content는 권한이 필요한 인텐트라 포털에서 켜지 않은 봇은 연결이 거부되므로, 음악 신청 채널을 쓸 때만 요청합니다.
+func (b *Bot) intents() []gateway.Intents {
+	intents := []gateway.Intents{gateway.IntentGuilds, gateway.IntentGuildVoiceStates}
+	if b.requestChannels {
+		intents = append(intents, gateway.IntentGuildMessages, gateway.IntentMessageContent)
 	}
-
-	slog.Info("Lavalink 노드 연결 완료", "name", node.Config().Name)
-	return nil
+	return intents
 }
 
 func (b *Bot) GetOrCreatePlayer(guildID snowflake.ID) *player.GuildPlayer {
@@ -133,7 +222,12 @@ func (b *Bot) GetOrCreatePlayer(guildID snowflake.ID) *player.GuildPlayer {
 		return gp
 	}
 
-	gp := player.NewGuildPlayer(guildID)
+	gp := player.NewGuildPlayer(guildID, b.Settings.Get(guildID).Volume())
+	gp.OnChange = b.playerChanged
 	b.Players[guildID] = gp
+
+	b.streamMu.Lock()
+	b.streamStates[guildID] = streamStateOf(gp)
+	b.streamMu.Unlock()
 	return gp
 }
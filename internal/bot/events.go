@@ -3,6 +3,7 @@ package bot
 import (
 	"context"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/disgoorg/disgo/discord"
@@ -10,33 +11,63 @@ import (
 	"github.com/disgoorg/disgolink/v3/disgolink"
 	"github.com/disgoorg/disgolink/v3/lavalink"
 	"github.com/disgoorg/snowflake/v2"
+	"github.com/uzih05/discord-music-bot/internal/api"
 	"github.com/uzih05/discord-music-bot/internal/embed"
 	"github.com/uzih05/discord-music-bot/internal/player"
 )
 
-const idleTimeout = 3 * time.Minute
-
 func (b *Bot) onVoiceStateUpdate(event *events.GuildVoiceStateUpdate) {
+	b.rememberMember(event.Member)
 	if event.VoiceState.UserID != b.Client.ApplicationID() {
+		b.pruneSkipVotes(event.VoiceState.GuildID)
 		return
 	}
 	b.Lavalink.OnVoiceStateUpdate(context.TODO(), event.VoiceState.GuildID, event.VoiceState.ChannelID, event.VoiceState.SessionID)
 
 	if event.VoiceState.ChannelID == nil {
 		b.mu.Lock()
-		if gp, ok := b.Players[event.VoiceState.GuildID]; ok {
+		delete(b.voice, event.VoiceState.GuildID)
+		gp, ok := b.Players[event.VoiceState.GuildID]
+		b.mu.Unlock()
+		// Clear가 부르는 OnChange가 b.mu를 다시 잡으므로 잠금을 푼 뒤 호출
+		if ok {
 			gp.Clear()
 		}
-		b.mu.Unlock()
+		b.Events.Publish(event.VoiceState.GuildID, api.EventDisconnect, nil)
+		return
 	}
+
+	b.mu.Lock()
+	vs := b.voice[event.VoiceState.GuildID]
+	vs.SessionID = event.VoiceState.SessionID
+	b.voice[event.VoiceState.GuildID] = vs
+	b.mu.Unlock()
 }
 
 func (b *Bot) onVoiceServerUpdate(event *events.VoiceServerUpdate) {
+	if event.Endpoint == nil {
+		return
+	}
+
+	// 노드 장애 시 다른 노드로 음성 연결을 넘기기 위해 보관
+	b.mu.Lock()
+	vs := b.voice[event.GuildID]
+	vs.Token = event.Token
+	vs.Endpoint = *event.Endpoint
+	b.voice[event.GuildID] = vs
+	b.mu.Unlock()
+
 	b.Lavalink.OnVoiceServerUpdate(context.TODO(), event.GuildID, event.Token, *event.Endpoint)
 }
 
 func (b *Bot) onApplicationCommand(event *events.ApplicationCommandInteractionCreate) {
 	data := event.SlashCommandInteractionData()
+	b.metrics.commands.Inc(data.CommandName())
+	if !b.authorize(*event.GuildID(), event.Member(), data.CommandName(), b.commandTrack(event)) {
+		b.respondEphemeral(event, permissionDenied)
+		return
+	}
+
 	switch data.CommandName() {
 	case "play":
 		b.handlePlay(event)
@@ -44,6 +75,14 @@ func (b *Bot) onApplicationCommand(event *events.ApplicationCommandInteractionCr
 		b.handlePause(event)
 	case "skip":
 		b.handleSkip(event)
+	case "previous":
+		b.handlePrevious(event)
+	case "seek":
+		b.handleSeek(event)
+	case "forward":
+		b.handleForward(event)
+	case "rewind":
+		b.handleRewind(event)
 	case "stop":
 		b.handleStop(event)
 	case "queue":
@@ -52,14 +91,38 @@ func (b *Bot) onApplicationCommand(event *events.ApplicationCommandInteractionCr
 		b.handleMove(event)
 	case "remove":
 		b.handleRemove(event)
+	case "remove-mine":
+		b.handleRemoveMine(event)
 	case "volume":
 		b.handleVolume(event)
 	case "repeat":
 		b.handleRepeat(event)
+	case "queuemode":
+		b.handleQueueMode(event)
+	case "autoplay":
+		b.handleAutoplay(event)
+	case "filter":
+		b.handleFilter(event)
+	case "eq":
+		b.handleEq(event)
 	case "shuffle":
 		b.handleShuffle(event)
 	case "nowplaying":
 		b.handleNowPlaying(event)
+	case "history":
+		b.handleHistory(event)
+	case "lyrics":
+		b.handleLyrics(event)
+	case "playlist":
+		b.handlePlaylistCommand(event)
+	case "source":
+		b.handleSource(event)
+	case "settings":
+		b.handleSettings(event)
+	case "dj":
+		b.handleDJ(event)
+	case "api":
+		b.handleAPICommand(event)
 	case "help":
 		b.handleHelp(event)
 	}
@@ -70,6 +133,13 @@ func (b *Bot) onComponentInteraction(event *events.ComponentInteractionCreate) {
 	b.handleComponentInteraction(event, customID)
 }
 
+func (b *Bot) onModalSubmit(event *events.ModalSubmitInteractionCreate) {
+	customID := event.Data.CustomID
+	if strings.HasPrefix(customID, "queue_") {
+		b.handleQueueModal(event, customID)
+	}
+}
+
 func (b *Bot) onTrackStart(p disgolink.Player, event lavalink.TrackStartEvent) {
 	guildID := p.GuildID()
 	gp := b.GetOrCreatePlayer(guildID)
@@ -77,29 +147,27 @@ func (b *Bot) onTrackStart(p disgolink.Player, event lavalink.TrackStartEvent) {
 	gp.StopUpdateLoop()
 	b.deleteIdleMessage(gp)
 	gp.CancelIdleTimer()
+	gp.ResetSkipVotes()
 
-	gp.Mu.Lock()
-	channelID := gp.TextChannelID
-	gp.Mu.Unlock()
+	b.metrics.tracksPlayed.Inc(event.Track.Info.SourceName)
+	b.schedulePanelUpdate(guildID)
+	b.Events.Publish(guildID, api.EventTrackStart, api.TrackEventData{Track: api.NewTrack(event.Track)})
 
+	channelID := b.announceChannel(gp)
 	if channelID == 0 {
 		return
 	}
 
-	e := embed.NowPlayingEmbed(event.Track, gp, p.Position())
-	buttons := embed.NowPlayingButtons(gp)
-	msg, err := b.Client.Rest().CreateMessage(channelID, discord.NewMessageCreateBuilder().
-		AddEmbeds(e).
-		AddContainerComponents(buttons...).
-		Build())
-	if err != nil {
-		slog.Error("Now Playing 메시지 전송 실패", "error", err)
-		return
+	// 신청 채널에서는 새 메시지 대신 고정된 패널이 Now Playing 역할을 함
+	if channelID != b.Settings.Get(guildID).RequestChannelID {
+		e := embed.NowPlayingEmbed(event.Track, gp, p.Position())
+		if err := b.showNowPlaying(gp, channelID, e, embed.NowPlayingButtons(gp)); err != nil {
+			slog.Error("Now Playing 메시지 전송 실패", "error", err)
+			return
+		}
 	}
 
 	gp.Mu.Lock()
-	gp.NowPlayingMessageID = msg.ID
-	gp.NowPlayingChannelID = channelID
 	stopCh := make(chan struct{})
 	gp.StopUpdateCh = stopCh
 	gp.Mu.Unlock()
@@ -117,6 +185,9 @@ func (b *Bot) nowPlayingUpdateLoop(guildID snowflake.ID, stopCh chan struct{}) {
 			return
 		case <-ticker.C:
 			b.updateNowPlayingEmbed(guildID)
+			b.schedulePanelUpdate(guildID)
+			b.updateLyricsViews(guildID)
+			b.scheduleSave(guildID)
 		}
 	}
 }
@@ -154,19 +225,36 @@ func (b *Bot) onTrackEnd(p disgolink.Player, event lavalink.TrackEndEvent) {
 	guildID := p.GuildID()
 	gp := b.GetOrCreatePlayer(guildID)
 
-	b.deleteNowPlaying(gp)
+	b.finishNowPlaying(gp)
+	b.Events.Publish(guildID, api.EventTrackEnd, api.TrackEndData{Track: api.NewTrack(event.Track), Reason: string(event.Reason)})
 
 	if !event.Reason.MayStartNext() {
 		return
 	}
+	b.playNext(p, gp)
+}
 
+// playNext는 대기열의 다음 곡을 재생합니다. 대기열이 비었으면 자동 재생을 시도하고, 그것도 안 되면 유휴 타이머를 시작합니다.
+func (b *Bot) playNext(p disgolink.Player, gp *player.GuildPlayer) {
+	guildID := gp.GuildID
 	nextTrack := gp.Next()
 	if nextTrack == nil {
+		if b.Settings.Get(guildID).Autoplay {
+			_, err := b.autoplay(context.TODO(), p, gp)
+			if err == nil {
+				return
+			}
+			slog.Warn("자동 재생 실패", "guild", guildID, "error", err)
+		}
 		b.startIdleTimer(guildID, gp)
 		return
 	}
 
-	if err := p.Update(context.TODO(), lavalink.WithTrack(*nextTrack)); err != nil {
+	// 필터는 플레이어가 새로 만들어졌을 수도 있으므로 곡마다 다시 적용
+	if err := p.Update(context.TODO(),
+		lavalink.WithTrack(*nextTrack),
+		lavalink.WithFilters(gp.FilterState().Build()),
+	); err != nil {
 		slog.Error("다음 곡 재생 실패", "error", err)
 	}
 }
@@ -175,21 +263,94 @@ func (b *Bot) onTrackException(p disgolink.Player, event lavalink.TrackException
 	guildID := p.GuildID()
 	gp := b.GetOrCreatePlayer(guildID)
 	slog.Error("트랙 예외 발생", "guild", guildID, "error", event.Exception.Message)
-	b.deleteNowPlaying(gp)
+	b.metrics.exceptions.Inc(string(event.Exception.Severity))
+	b.finishNowPlaying(gp)
+	b.Events.Publish(guildID, api.EventTrackException, api.TrackExceptionData{
+		Track:    api.NewTrack(event.Track),
+		Message:  event.Exception.Message,
+		Severity: string(event.Exception.Severity),
+		Cause:    event.Exception.Cause,
+	})
 }
 
 func (b *Bot) onTrackStuck(p disgolink.Player, event lavalink.TrackStuckEvent) {
 	guildID := p.GuildID()
 	gp := b.GetOrCreatePlayer(guildID)
 	slog.Warn("트랙이 멈춤", "guild", guildID, "threshold", event.Threshold)
-	b.deleteNowPlaying(gp)
+	b.metrics.stuck.Inc()
+	b.finishNowPlaying(gp)
+	b.Events.Publish(guildID, api.EventTrackStuck, api.TrackStuckData{Track: api.NewTrack(event.Track), Threshold: int64(event.Threshold)})
 
-	nextTrack := gp.Next()
-	if nextTrack != nil {
-		_ = p.Update(context.TODO(), lavalink.WithTrack(*nextTrack))
+	// 멈춘 곡은 끝난 곡과 같이 다음 곡(필터, 자동 재생, 유휴 타이머 포함)으로 넘김
+	b.playNext(p, gp)
+
+	// 넘길 곡이 없으면 멈춘 곡을 정지해, 나중에 끝나더라도 유휴 처리가 한 번 더 일어나지 않게 함
+	gp.Mu.Lock()
+	idle := gp.CurrentTrack == nil
+	gp.Mu.Unlock()
+	if idle {
+		_ = p.Update(context.TODO(), lavalink.WithNullTrack())
 	}
 }
 
+// nowPlayingScrollLimit개 이상의 메시지에 밀려 올라간 고정 Now Playing 메시지는 새로 보냅니다.
+const nowPlayingScrollLimit = 10
+
+// showNowPlaying은 channelID에 Now Playing 메시지를 보냅니다.
+// 고정 모드에서는 기존 메시지를 수정하고, 메시지가 지워졌거나 채널이 바뀌었거나 너무 위로 밀려났을 때만 새로 보냅니다.
+func (b *Bot) showNowPlaying(gp *player.GuildPlayer, channelID snowflake.ID, e discord.Embed, components []discord.ContainerComponent) error {
+	gp.Mu.Lock()
+	msgID := gp.NowPlayingMessageID
+	chID := gp.NowPlayingChannelID
+	gp.Mu.Unlock()
+
+	if msgID != 0 && chID == channelID && b.Settings.Get(gp.GuildID).PersistentNowPlaying && !b.nowPlayingBuried(chID, msgID) {
+		_, err := b.Client.Rest().UpdateMessage(chID, msgID, discord.NewMessageUpdateBuilder().
+			SetEmbeds(e).
+			SetContainerComponents(components...).
+			Build())
+		if err == nil || !isNotFound(err) {
+			return err
+		}
+	}
+	if msgID != 0 {
+		_ = b.Client.Rest().DeleteMessage(chID, msgID)
+	}
+
+	msg, err := b.Client.Rest().CreateMessage(channelID, discord.NewMessageCreateBuilder().
+		AddEmbeds(e).
+		AddContainerComponents(components...).
+		Build())
+	if err != nil {
+		return err
+	}
+
+	gp.Mu.Lock()
+	gp.NowPlayingMessageID = msg.ID
+	gp.NowPlayingChannelID = channelID
+	gp.Mu.Unlock()
+	return nil
+}
+
+// nowPlayingBuried는 메시지 뒤에 다른 메시지가 nowPlayingScrollLimit개 이상 쌓였는지 확인합니다.
+func (b *Bot) nowPlayingBuried(channelID, messageID snowflake.ID) bool {
+	msgs, err := b.Client.Rest().GetMessages(channelID, 0, 0, messageID, nowPlayingScrollLimit)
+	if err != nil {
+		return false
+	}
+	return len(msgs) >= nowPlayingScrollLimit
+}
+
+// finishNowPlaying은 곡이 끝났을 때 호출됩니다.
+// 고정 모드에서는 다음 곡이나 대기 상태로 수정할 수 있도록 메시지를 남겨두고 갱신만 멈춥니다.
+func (b *Bot) finishNowPlaying(gp *player.GuildPlayer) {
+	if b.Settings.Get(gp.GuildID).PersistentNowPlaying {
+		gp.StopUpdateLoop()
+		return
+	}
+	b.deleteNowPlaying(gp)
+}
+
 func (b *Bot) deleteNowPlaying(gp *player.GuildPlayer) {
 	gp.StopUpdateLoop()
 
@@ -205,30 +366,57 @@ func (b *Bot) deleteNowPlaying(gp *player.GuildPlayer) {
 	}
 }
 
-func (b *Bot) startIdleTimer(guildID snowflake.ID, gp *player.GuildPlayer) {
+// announceChannel은 Now Playing과 대기 중 메시지를 보낼 채널입니다.
+// 길드에 안내 채널이 설정되어 있으면 명령어를 쓴 채널 대신 그 채널을 사용합니다.
+func (b *Bot) announceChannel(gp *player.GuildPlayer) snowflake.ID {
+	if channelID := b.Settings.Get(gp.GuildID).AnnounceChannelID; channelID != 0 {
+		return channelID
+	}
 	gp.Mu.Lock()
-	channelID := gp.TextChannelID
-	gp.Mu.Unlock()
+	defer gp.Mu.Unlock()
+	return gp.TextChannelID
+}
+
+func (b *Bot) startIdleTimer(guildID snowflake.ID, gp *player.GuildPlayer) {
+	g := b.Settings.Get(guildID)
+	timeout, leave := g.IdleTimeout()
+	b.Events.Publish(guildID, api.EventIdle, api.IdleData{Timeout: timeout.Milliseconds(), Leave: leave})
 
+	channelID := b.announceChannel(gp)
 	if channelID == 0 {
 		return
 	}
 
-	e := embed.IdleEmbed()
-	msg, err := b.Client.Rest().CreateMessage(channelID, discord.NewMessageCreateBuilder().
-		AddEmbeds(e).
-		Build())
-	if err != nil {
-		slog.Error("대기 중 메시지 전송 실패", "error", err)
-		return
+	// 신청 채널에서는 패널이, 고정 모드에서는 Now Playing 메시지가 대기 상태를 보여주므로 메시지를 따로 보내지 않음
+	var msgID snowflake.ID
+	switch {
+	case channelID == g.RequestChannelID:
+	case g.PersistentNowPlaying:
+		if err := b.showNowPlaying(gp, channelID, embed.IdleEmbed(timeout, leave), []discord.ContainerComponent{}); err != nil {
+			slog.Error("대기 중 메시지 전송 실패", "error", err)
+			return
+		}
+	default:
+		msg, err := b.Client.Rest().CreateMessage(channelID, discord.NewMessageCreateBuilder().
+			AddEmbeds(embed.IdleEmbed(timeout, leave)).
+			Build())
+		if err != nil {
+			slog.Error("대기 중 메시지 전송 실패", "error", err)
+			return
+		}
+		msgID = msg.ID
 	}
 
 	gp.Mu.Lock()
-	gp.IdleMessageID = msg.ID
-	gp.IdleChannelID = channelID
-	gp.IdleTimer = time.AfterFunc(idleTimeout, func() {
-		b.handleIdleTimeout(guildID)
-	})
+	if msgID != 0 {
+		gp.IdleMessageID = msgID
+		gp.IdleChannelID = channelID
+	}
+	if leave {
+		gp.IdleTimer = time.AfterFunc(timeout, func() {
+			b.handleIdleTimeout(guildID)
+		})
+	}
 	gp.Mu.Unlock()
 }
 
@@ -236,6 +424,7 @@ func (b *Bot) handleIdleTimeout(guildID snowflake.ID) {
 	gp := b.GetOrCreatePlayer(guildID)
 
 	b.deleteIdleMessage(gp)
+	b.deleteNowPlaying(gp)
 
 	p := b.Lavalink.ExistingPlayer(guildID)
 	if p != nil {
@@ -0,0 +1,90 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgolink/v3/lavalink"
+	"github.com/uzih05/discord-music-bot/internal/embed"
+	"github.com/uzih05/discord-music-bot/internal/player"
+)
+
+// applyFilters는 길드에 저장된 필터 설정을 Lavalink 플레이어에 반영합니다.
+func (b *Bot) applyFilters(gp *player.GuildPlayer) error {
+	p := b.Lavalink.ExistingPlayer(gp.GuildID)
+	if p == nil {
+		return nil
+	}
+	if err := p.Update(context.TODO(), lavalink.WithFilters(gp.FilterState().Build())); err != nil {
+		return err
+	}
+	b.updateNowPlayingEmbed(gp.GuildID)
+	return nil
+}
+
+func (b *Bot) handleFilter(event *events.ApplicationCommandInteractionCreate) {
+	name := event.SlashCommandInteractionData().String("preset")
+	gp := b.GetOrCreatePlayer(*event.GuildID())
+
+	var content string
+	if name == "off" {
+		gp.ClearFilters()
+		content = "모든 필터를 껐습니다."
+	} else {
+		enabled, err := gp.TogglePreset(name)
+		if err != nil {
+			b.respondEphemeral(event, err.Error())
+			return
+		}
+		preset, _ := player.FindPreset(name)
+		content = fmt.Sprintf("**%s** 필터를 껐습니다.", preset.Label)
+		if enabled {
+			content = fmt.Sprintf("**%s** 필터를 켰습니다.", preset.Label)
+		}
+	}
+
+	if err := b.applyFilters(gp); err != nil {
+		b.respondEphemeral(event, "필터 적용 실패: "+err.Error())
+		return
+	}
+	b.respondEphemeral(event, content)
+}
+
+func (b *Bot) handleEq(event *events.ApplicationCommandInteractionCreate) {
+	data := event.SlashCommandInteractionData()
+	if data.SubCommandName == nil {
+		return
+	}
+	gp := b.GetOrCreatePlayer(*event.GuildID())
+
+	switch *data.SubCommandName {
+	case "set":
+		band := data.Int("band")
+		gain := float32(data.Float("gain"))
+		if err := gp.SetEqBand(band, gain); err != nil {
+			b.respondEphemeral(event, err.Error())
+			return
+		}
+		if err := b.applyFilters(gp); err != nil {
+			b.respondEphemeral(event, "이퀄라이저 적용 실패: "+err.Error())
+			return
+		}
+		b.respondEphemeral(event, fmt.Sprintf("%d번 밴드 게인을 **%+.2f**(으)로 설정했습니다.", band, gain))
+
+	case "reset":
+		gp.ResetEqualizer()
+		if err := b.applyFilters(gp); err != nil {
+			b.respondEphemeral(event, "이퀄라이저 적용 실패: "+err.Error())
+			return
+		}
+		b.respondEphemeral(event, "이퀄라이저를 초기화했습니다.")
+
+	case "show":
+		_ = event.CreateMessage(discord.NewMessageCreateBuilder().
+			AddEmbeds(embed.FilterEmbed(gp.FilterState())).
+			SetEphemeral(true).
+			Build())
+	}
+}
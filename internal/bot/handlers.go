@@ -2,6 +2,7 @@ package bot
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"regexp"
@@ -14,13 +15,17 @@ import (
 	"github.com/disgoorg/disgolink/v3/disgolink"
 	"github.com/disgoorg/disgolink/v3/lavalink"
 	"github.com/disgoorg/snowflake/v2"
+	"github.com/uzih05/discord-music-bot/internal/api"
 	"github.com/uzih05/discord-music-bot/internal/embed"
 	"github.com/uzih05/discord-music-bot/internal/player"
 	"github.com/uzih05/discord-music-bot/internal/search"
+	"github.com/uzih05/discord-music-bot/internal/settings"
 )
 
 var urlPattern = regexp.MustCompile(`^https?://`)
 
+var errNothingPlaying = errors.New("재생 중인 곡이 없습니다")
+
 func (b *Bot) respondEphemeral(event *events.ApplicationCommandInteractionCreate, content string) {
 	_ = event.CreateMessage(discord.NewMessageCreateBuilder().
 		SetContent(content).
@@ -40,40 +45,31 @@ func (b *Bot) handlePlay(event *events.ApplicationCommandInteractionCreate) {
 	data := event.SlashCommandInteractionData()
 	query := data.String("query")
 
-	voiceState := b.getVoiceChannelID(event)
-	if voiceState == nil {
-		b.respondEphemeral(event, "먼저 음성 채널에 접속해주세요!")
-		return
-	}
-
-	_ = event.DeferCreateMessage(true)
-
 	isURL := urlPattern.MatchString(query)
 	searchQuery := query
 	if !isURL {
-		searchQuery = lavalink.SearchTypeYouTube.Apply(query)
+		searchQuery = b.searchSource(*event.GuildID(), data.String("source")).Query(query)
 	}
 
-	gp := b.GetOrCreatePlayer(*event.GuildID())
-	b.deleteIdleMessage(gp)
-	gp.CancelIdleTimer()
-	gp.Mu.Lock()
-	gp.TextChannelID = event.Channel().ID()
-	gp.Mu.Unlock()
+	gp, ok := b.joinVoice(event)
+	if !ok {
+		return
+	}
 
 	ctx := context.TODO()
-
-	if err := b.Client.UpdateVoiceState(ctx, *event.GuildID(), voiceState.ChannelID, false, false); err != nil {
-		b.updateResponse(event, "음성 채널 연결 실패: "+err.Error())
+	node := b.bestNode(*event.GuildID(), "")
+	if node == nil {
+		b.updateResponse(event, "사용 가능한 Lavalink 노드가 없습니다.")
 		return
 	}
 
-	b.Lavalink.BestNode().LoadTracksHandler(ctx, searchQuery, disgolink.NewResultHandler(
+	respond := b.responder(event)
+	b.loadTracks(ctx, node, searchQuery, disgolink.NewResultHandler(
 		func(track lavalink.Track) {
-			b.playOrQueue(event, gp, track)
+			b.playOrQueue(gp, player.WithRequester(track, event.User().ID), respond)
 		},
 		func(playlist lavalink.Playlist) {
-			b.handlePlaylist(event, gp, playlist)
+			b.handlePlaylist(gp, playlist, event.User().ID, respond)
 		},
 		func(tracks []lavalink.Track) {
 			if len(tracks) == 0 {
@@ -82,7 +78,7 @@ func (b *Bot) handlePlay(event *events.ApplicationCommandInteractionCreate) {
 			}
 
 			if isURL {
-				b.playOrQueue(event, gp, tracks[0])
+				b.playOrQueue(gp, player.WithRequester(tracks[0], event.User().ID), respond)
 				return
 			}
 
@@ -117,38 +113,88 @@ func (b *Bot) handlePlay(event *events.ApplicationCommandInteractionCreate) {
 	))
 }
 
-func (b *Bot) playOrQueue(event *events.ApplicationCommandInteractionCreate, gp *player.GuildPlayer, track lavalink.Track) {
+// joinVoice는 명령어를 쓴 사용자의 음성 채널에 접속하고 응답을 지연시킵니다.
+// 실패하면 사용자에게 응답한 뒤 false를 반환합니다.
+func (b *Bot) joinVoice(event *events.ApplicationCommandInteractionCreate) (*player.GuildPlayer, bool) {
+	voiceState := b.getVoiceChannelID(event)
+	if voiceState == nil {
+		b.respondEphemeral(event, "먼저 음성 채널에 접속해주세요!")
+		return nil, false
+	}
+
+	_ = event.DeferCreateMessage(true)
+
+	gp, err := b.connect(*event.GuildID(), event.Channel().ID(), voiceState.ChannelID)
+	if err != nil {
+		b.updateResponse(event, "음성 채널 연결 실패: "+err.Error())
+		return nil, false
+	}
+	return gp, true
+}
+
+// connect는 대기 상태를 해제하고 voiceChannelID에 접속합니다.
+// textChannelID는 이후 Now Playing 메시지를 보낼 채널로 기억합니다.
+func (b *Bot) connect(guildID, textChannelID snowflake.ID, voiceChannelID *snowflake.ID) (*player.GuildPlayer, error) {
+	gp := b.GetOrCreatePlayer(guildID)
+	b.deleteIdleMessage(gp)
+	gp.CancelIdleTimer()
+	gp.Mu.Lock()
+	gp.TextChannelID = textChannelID
+	gp.Mu.Unlock()
+
+	if err := b.Client.UpdateVoiceState(context.TODO(), guildID, voiceChannelID, false, false); err != nil {
+		return nil, err
+	}
+	return gp, nil
+}
+
+// searchSource는 /play에서 고른 소스를, 없으면 길드 기본 소스를 반환합니다.
+func (b *Bot) searchSource(guildID snowflake.ID, name string) search.Source {
+	if name == "" {
+		name = b.Settings.Get(guildID).SearchSource
+	}
+	source, _ := search.FindSource(name)
+	return source
+}
+
+// playOrQueue는 재생 중이 아니면 track을 바로 재생하고, 아니면 대기열에 추가한 뒤 결과를 respond로 알립니다.
+func (b *Bot) playOrQueue(gp *player.GuildPlayer, track lavalink.Track, respond func(content string)) {
 	ctx := context.TODO()
-	p := b.Lavalink.ExistingPlayer(*event.GuildID())
-	if p == nil {
-		p = b.Lavalink.Player(*event.GuildID())
-		_ = p.Update(ctx, lavalink.WithVolume(gp.Volume))
+	p := b.lavalinkPlayer(ctx, gp)
+
+	if accepted, note := b.limitTracks(gp, []lavalink.Track{track}, p.Track() != nil); len(accepted) == 0 {
+		respond(note)
+		return
 	}
 
 	if p.Track() == nil {
 		gp.SetCurrentTrack(&track)
 		if err := p.Update(ctx, lavalink.WithTrack(track)); err != nil {
-			b.updateResponse(event, "재생 실패: "+err.Error())
+			respond("재생 실패: " + err.Error())
 			return
 		}
-		b.updateResponse(event, fmt.Sprintf("**%s** 재생을 시작합니다!", track.Info.Title))
+		respond(fmt.Sprintf("**%s** 재생을 시작합니다!", track.Info.Title))
 	} else {
 		gp.Add(track)
-		b.updateResponse(event, fmt.Sprintf("**%s** 을(를) 대기열에 추가했습니다. (대기열: %d곡)", track.Info.Title, gp.QueueLen()))
+		respond(fmt.Sprintf("**%s** 을(를) 대기열에 추가했습니다. (대기열: %d곡)", track.Info.Title, gp.QueueLen()))
 	}
 }
 
-func (b *Bot) handlePlaylist(event *events.ApplicationCommandInteractionCreate, gp *player.GuildPlayer, playlist lavalink.Playlist) {
+func (b *Bot) handlePlaylist(gp *player.GuildPlayer, playlist lavalink.Playlist, userID snowflake.ID, respond func(content string)) {
 	ctx := context.TODO()
-	p := b.Lavalink.ExistingPlayer(*event.GuildID())
-	if p == nil {
-		p = b.Lavalink.Player(*event.GuildID())
-		_ = p.Update(ctx, lavalink.WithVolume(gp.Volume))
-	}
+	p := b.lavalinkPlayer(ctx, gp)
 
-	tracks := playlist.Tracks
+	tracks := make([]lavalink.Track, len(playlist.Tracks))
+	for i, track := range playlist.Tracks {
+		tracks[i] = player.WithRequester(track, userID)
+	}
+	if len(tracks) == 0 {
+		respond("플레이리스트가 비어있습니다.")
+		return
+	}
+	tracks, note := b.limitTracks(gp, tracks, p.Track() != nil)
 	if len(tracks) == 0 {
-		b.updateResponse(event, "플레이리스트가 비어있습니다.")
+		respond("추가할 수 있는 곡이 없습니다. " + note)
 		return
 	}
 
@@ -156,14 +202,76 @@ func (b *Bot) handlePlaylist(event *events.ApplicationCommandInteractionCreate,
 		first := tracks[0]
 		gp.SetCurrentTrack(&first)
 		if err := p.Update(ctx, lavalink.WithTrack(first)); err != nil {
-			b.updateResponse(event, "재생 실패: "+err.Error())
+			respond("재생 실패: " + err.Error())
 			return
 		}
 		gp.Add(tracks[1:]...)
 	} else {
 		gp.Add(tracks...)
 	}
-	b.updateResponse(event, fmt.Sprintf("플레이리스트 **%s**에서 %d곡을 추가했습니다.", playlist.Info.Name, len(tracks)))
+	content := fmt.Sprintf("플레이리스트 **%s**에서 %d곡을 추가했습니다.", playlist.Info.Name, len(tracks))
+	if note != "" {
+		content += "\n" + note
+	}
+	respond(content)
+}
+
+// enqueueSearchResults는 검색 결과에서 고른 곡을 순서대로 재생/대기열에 추가하고 검색 메시지를 결과로 바꿉니다.
+func (b *Bot) enqueueSearchResults(event *events.ComponentInteractionCreate, ps *search.PendingSearch, selected []lavalink.Track) {
+	update := func(content string) {
+		_ = event.UpdateMessage(discord.NewMessageUpdateBuilder().
+			SetContent(content).
+			SetEmbeds().
+			SetContainerComponents().
+			Build())
+	}
+	if len(selected) == 0 {
+		update("선택한 곡이 없습니다.")
+		return
+	}
+
+	tracks := make([]lavalink.Track, len(selected))
+	for i, track := range selected {
+		tracks[i] = player.WithRequester(track, ps.UserID)
+	}
+
+	gp := b.GetOrCreatePlayer(ps.GuildID)
+	ctx := context.TODO()
+	p := b.lavalinkPlayer(ctx, gp)
+
+	tracks, note := b.limitTracks(gp, tracks, p.Track() != nil)
+	if len(tracks) == 0 {
+		update(note)
+		return
+	}
+	selected = tracks
+	if note != "" {
+		note = "\n" + note
+	}
+
+	started := false
+	if p.Track() == nil {
+		first := tracks[0]
+		gp.SetCurrentTrack(&first)
+		if err := p.Update(ctx, lavalink.WithTrack(first)); err != nil {
+			update("재생 실패: " + err.Error())
+			return
+		}
+		tracks = tracks[1:]
+		started = true
+	}
+	gp.Add(tracks...)
+
+	switch {
+	case started && len(tracks) == 0:
+		update(fmt.Sprintf("**%s** 재생을 시작합니다!", selected[0].Info.Title) + note)
+	case started:
+		update(fmt.Sprintf("**%s** 재생을 시작하고, %d곡을 대기열에 추가했습니다. (대기열: %d곡)", selected[0].Info.Title, len(tracks), gp.QueueLen()) + note)
+	case len(tracks) == 1:
+		update(fmt.Sprintf("**%s** 을(를) 대기열에 추가했습니다. (대기열: %d곡)", tracks[0].Info.Title, gp.QueueLen()) + note)
+	default:
+		update(fmt.Sprintf("%d곡을 대기열에 추가했습니다. (대기열: %d곡)", len(tracks), gp.QueueLen()) + note)
+	}
 }
 
 func (b *Bot) handleComponentInteraction(event *events.ComponentInteractionCreate, customID string) {
@@ -173,6 +281,24 @@ func (b *Bot) handleComponentInteraction(event *events.ComponentInteractionCreat
 		return
 	}
 
+	// 재생 기록 페이지 버튼 처리
+	if strings.HasPrefix(customID, "history_") {
+		b.handleHistoryButton(event, customID)
+		return
+	}
+
+	// 가사 페이지 버튼 처리
+	if strings.HasPrefix(customID, "lyrics_") {
+		b.handleLyricsButton(event, customID)
+		return
+	}
+
+	// 대기열 페이지 버튼/선택 메뉴 처리
+	if strings.HasPrefix(customID, "queue_") {
+		b.handleQueueComponent(event, customID)
+		return
+	}
+
 	// 검색 결과 버튼 처리
 	messageID := event.Message.ID
 
@@ -194,52 +320,23 @@ func (b *Bot) handleComponentInteraction(event *events.ComponentInteractionCreat
 	}
 
 	switch {
-	case strings.HasPrefix(customID, "search_select:"):
-		indexStr := strings.TrimPrefix(customID, "search_select:")
-		index, err := strconv.Atoi(indexStr)
-		if err != nil {
-			return
-		}
-
-		globalIndex := ps.Page*search.PageSize + index
-		if globalIndex >= len(ps.Tracks) {
-			return
+	case customID == "search_select":
+		// 사용자가 고른 순서대로 대기열에 추가
+		var tracks []lavalink.Track
+		for _, value := range event.StringSelectMenuInteractionData().Values {
+			index, err := strconv.Atoi(value)
+			if err != nil || index < 0 || index >= len(ps.Tracks) {
+				continue
+			}
+			tracks = append(tracks, ps.Tracks[index])
 		}
-
-		track := ps.Tracks[globalIndex]
 		b.SearchCache.Delete(messageID)
+		b.enqueueSearchResults(event, ps, tracks)
 
-		gp := b.GetOrCreatePlayer(ps.GuildID)
-		ctx := context.TODO()
-		p := b.Lavalink.ExistingPlayer(ps.GuildID)
-		if p == nil {
-			p = b.Lavalink.Player(ps.GuildID)
-			_ = p.Update(ctx, lavalink.WithVolume(gp.Volume))
-		}
-
-		if p.Track() == nil {
-			gp.SetCurrentTrack(&track)
-			if err := p.Update(ctx, lavalink.WithTrack(track)); err != nil {
-				_ = event.UpdateMessage(discord.NewMessageUpdateBuilder().
-					SetContent("재생 실패: " + err.Error()).
-					SetEmbeds().
-					SetContainerComponents().
-					Build())
-				return
-			}
-			_ = event.UpdateMessage(discord.NewMessageUpdateBuilder().
-				SetContent(fmt.Sprintf("**%s** 재생을 시작합니다!", track.Info.Title)).
-				SetEmbeds().
-				SetContainerComponents().
-				Build())
-		} else {
-			gp.Add(track)
-			_ = event.UpdateMessage(discord.NewMessageUpdateBuilder().
-				SetContent(fmt.Sprintf("**%s** 을(를) 대기열에 추가했습니다. (대기열: %d곡)", track.Info.Title, gp.QueueLen())).
-				SetEmbeds().
-				SetContainerComponents().
-				Build())
-		}
+	case customID == "search_all":
+		tracks := ps.PageTracks()
+		b.SearchCache.Delete(messageID)
+		b.enqueueSearchResults(event, ps, tracks)
 
 	case customID == "search_prev":
 		if ps.Page > 0 {
@@ -279,7 +376,7 @@ func (b *Bot) handlePause(event *events.ApplicationCommandInteractionCreate) {
 	}
 
 	paused := !p.Paused()
-	if err := p.Update(context.TODO(), lavalink.WithPaused(paused)); err != nil {
+	if err := b.setPaused(*event.GuildID(), paused); err != nil {
 		b.respondEphemeral(event, "조작 실패: "+err.Error())
 		return
 	}
@@ -291,6 +388,22 @@ func (b *Bot) handlePause(event *events.ApplicationCommandInteractionCreate) {
 	}
 }
 
+// setPaused는 일시정지 상태를 바꾸고 상태 저장과 패널 수정을 예약합니다.
+func (b *Bot) setPaused(guildID snowflake.ID, paused bool) error {
+	p := b.Lavalink.ExistingPlayer(guildID)
+	if p == nil {
+		return errNothingPlaying
+	}
+	if err := p.Update(context.TODO(), lavalink.WithPaused(paused)); err != nil {
+		return err
+	}
+
+	b.scheduleSave(guildID)
+	b.schedulePanelUpdate(guildID)
+	b.Events.Publish(guildID, api.EventPauseUpdate, api.PauseData{Paused: paused})
+	return nil
+}
+
 func (b *Bot) handleSkip(event *events.ApplicationCommandInteractionCreate) {
 	p := b.Lavalink.ExistingPlayer(*event.GuildID())
 	if p == nil {
@@ -299,18 +412,122 @@ func (b *Bot) handleSkip(event *events.ApplicationCommandInteractionCreate) {
 	}
 
 	gp := b.GetOrCreatePlayer(*event.GuildID())
+	skip, votes, needed, err := b.skipVote(*event.GuildID(), event.Member(), gp)
+	if err != nil {
+		b.respondEphemeral(event, err.Error())
+		return
+	}
+	if !skip {
+		b.respondEphemeral(event, fmt.Sprintf("스킵에 투표했습니다. (%d/%d)", votes, needed))
+		b.updateNowPlayingEmbed(*event.GuildID())
+		return
+	}
+
+	content, err := b.skipTrack(p, gp)
+	if err != nil {
+		b.respondEphemeral(event, "스킵 실패: "+err.Error())
+		return
+	}
+	b.respondEphemeral(event, content)
+}
+
+// skipTrack은 투표 없이 다음 곡으로 넘어가고 결과 안내 문구를 반환합니다.
+// 대기열이 비었으면 자동 재생 곡을 찾거나 재생을 멈춥니다.
+func (b *Bot) skipTrack(p disgolink.Player, gp *player.GuildPlayer) (string, error) {
 	nextTrack := gp.Next()
 	if nextTrack == nil {
+		if b.Settings.Get(gp.GuildID).Autoplay {
+			go b.skipToAutoplay(p, gp)
+			return "대기열이 비었습니다. 자동 재생할 곡을 찾습니다.", nil
+		}
 		_ = p.Update(context.TODO(), lavalink.WithNullTrack())
-		b.respondEphemeral(event, "대기열이 비었습니다. 재생을 종료합니다.")
-		return
+		return "대기열이 비었습니다. 재생을 종료합니다.", nil
 	}
 
 	if err := p.Update(context.TODO(), lavalink.WithTrack(*nextTrack)); err != nil {
-		b.respondEphemeral(event, "스킵 실패: "+err.Error())
+		return "", err
+	}
+	return fmt.Sprintf("스킵! 다음 곡: **%s**", nextTrack.Info.Title), nil
+}
+
+func (b *Bot) handlePrevious(event *events.ApplicationCommandInteractionCreate) {
+	p := b.Lavalink.ExistingPlayer(*event.GuildID())
+	if p == nil {
+		b.respondEphemeral(event, "재생 중인 곡이 없습니다.")
 		return
 	}
-	b.respondEphemeral(event, fmt.Sprintf("스킵! 다음 곡: **%s**", nextTrack.Info.Title))
+
+	gp := b.GetOrCreatePlayer(*event.GuildID())
+	prevTrack := gp.Previous()
+	if prevTrack == nil {
+		b.respondEphemeral(event, "이전 곡이 없습니다.")
+		return
+	}
+
+	if err := p.Update(context.TODO(), lavalink.WithTrack(*prevTrack)); err != nil {
+		b.respondEphemeral(event, "이전 곡 재생 실패: "+err.Error())
+		return
+	}
+	b.respondEphemeral(event, fmt.Sprintf("이전 곡: **%s**", prevTrack.Info.Title))
+}
+
+func (b *Bot) handleSeek(event *events.ApplicationCommandInteractionCreate) {
+	data := event.SlashCommandInteractionData()
+	b.respondSeek(event, data.String("time"))
+}
+
+func (b *Bot) handleForward(event *events.ApplicationCommandInteractionCreate) {
+	data := event.SlashCommandInteractionData()
+	seconds, ok := data.OptInt("seconds")
+	if !ok {
+		seconds = 10
+	}
+	b.respondSeek(event, fmt.Sprintf("+%d", seconds))
+}
+
+func (b *Bot) handleRewind(event *events.ApplicationCommandInteractionCreate) {
+	data := event.SlashCommandInteractionData()
+	seconds, ok := data.OptInt("seconds")
+	if !ok {
+		seconds = 10
+	}
+	b.respondSeek(event, fmt.Sprintf("-%d", seconds))
+}
+
+func (b *Bot) respondSeek(event *events.ApplicationCommandInteractionCreate, input string) {
+	position, err := b.seekTo(*event.GuildID(), input)
+	if err != nil {
+		b.respondEphemeral(event, "탐색 실패: "+err.Error())
+		return
+	}
+
+	b.respondEphemeral(event, fmt.Sprintf("`%s` 위치로 이동했습니다.", embed.FormatDuration(position)))
+	b.updateNowPlayingEmbed(*event.GuildID())
+}
+
+// seekTo는 현재 곡의 재생 위치를 input(절대 또는 +/- 상대 시간)으로 옮깁니다.
+func (b *Bot) seekTo(guildID snowflake.ID, input string) (lavalink.Duration, error) {
+	p := b.Lavalink.ExistingPlayer(guildID)
+	if p == nil || p.Track() == nil {
+		return 0, errNothingPlaying
+	}
+
+	target, err := player.SeekTarget(input, p.Position(), *p.Track())
+	if err != nil {
+		return 0, err
+	}
+
+	if err := p.Update(context.TODO(), lavalink.WithPosition(target)); err != nil {
+		return 0, err
+	}
+
+	// 다음 playerUpdate가 오기 전까지 Position()이 이전 값을 반환하므로 로컬 상태를 맞춰 둠
+	state := p.State()
+	state.Position = target
+	state.Time = lavalink.Now()
+	p.OnPlayerUpdate(state)
+
+	return target, nil
 }
 
 func (b *Bot) handleStop(event *events.ApplicationCommandInteractionCreate) {
@@ -329,16 +546,6 @@ func (b *Bot) handleStop(event *events.ApplicationCommandInteractionCreate) {
 	b.respondEphemeral(event, "재생을 중지하고 음성 채널에서 나갔습니다.")
 }
 
-func (b *Bot) handleQueue(event *events.ApplicationCommandInteractionCreate) {
-	gp := b.GetOrCreatePlayer(*event.GuildID())
-	e := embed.QueueEmbed(gp)
-
-	_ = event.CreateMessage(discord.NewMessageCreateBuilder().
-		AddEmbeds(e).
-		SetEphemeral(true).
-		Build())
-}
-
 func (b *Bot) handleMove(event *events.ApplicationCommandInteractionCreate) {
 	data := event.SlashCommandInteractionData()
 	from := data.Int("from")
@@ -353,7 +560,7 @@ func (b *Bot) handleMove(event *events.ApplicationCommandInteractionCreate) {
 
 	track, ok := gp.Move(from, to)
 	if !ok {
-		b.respondEphemeral(event, "잘못된 위치입니다. /queue로 대기열을 확인하세요.")
+		b.respondEphemeral(event, "잘못된 위치입니다. `/queue view`로 대기열을 확인하세요.")
 		return
 	}
 
@@ -368,29 +575,34 @@ func (b *Bot) handleRemove(event *events.ApplicationCommandInteractionCreate) {
 
 	track, ok := gp.Remove(pos)
 	if !ok {
-		b.respondEphemeral(event, "잘못된 위치입니다. /queue로 대기열을 확인하세요.")
+		b.respondEphemeral(event, "잘못된 위치입니다. `/queue view`로 대기열을 확인하세요.")
 		return
 	}
 
 	b.respondEphemeral(event, fmt.Sprintf("**%s**을(를) 대기열에서 삭제했습니다.", track.Info.Title))
 }
 
-func (b *Bot) handleVolume(event *events.ApplicationCommandInteractionCreate) {
-	data := event.SlashCommandInteractionData()
-	level := data.Int("level")
+func (b *Bot) handleRemoveMine(event *events.ApplicationCommandInteractionCreate) {
+	gp := b.GetOrCreatePlayer(*event.GuildID())
 
-	p := b.Lavalink.ExistingPlayer(*event.GuildID())
-	if p == nil {
-		b.respondEphemeral(event, "재생 중인 곡이 없습니다.")
+	removed := gp.RemoveRequester(event.User().ID)
+	if len(removed) == 0 {
+		b.respondEphemeral(event, "대기열에 내가 신청한 곡이 없습니다.")
 		return
 	}
 
-	gp := b.GetOrCreatePlayer(*event.GuildID())
-	gp.Mu.Lock()
-	gp.Volume = level
-	gp.Mu.Unlock()
+	b.respondEphemeral(event, fmt.Sprintf("내가 신청한 곡 %d개를 대기열에서 삭제했습니다.", len(removed)))
+}
+
+func (b *Bot) handleVolume(event *events.ApplicationCommandInteractionCreate) {
+	data := event.SlashCommandInteractionData()
+	level := data.Int("level")
 
-	if err := p.Update(context.TODO(), lavalink.WithVolume(level)); err != nil {
+	if err := b.setVolume(*event.GuildID(), level); err != nil {
+		if errors.Is(err, errNothingPlaying) {
+			b.respondEphemeral(event, "재생 중인 곡이 없습니다.")
+			return
+		}
 		b.respondEphemeral(event, "볼륨 조절 실패: "+err.Error())
 		return
 	}
@@ -398,27 +610,68 @@ func (b *Bot) handleVolume(event *events.ApplicationCommandInteractionCreate) {
 	b.updateNowPlayingEmbed(*event.GuildID())
 }
 
+func (b *Bot) setVolume(guildID snowflake.ID, level int) error {
+	p := b.Lavalink.ExistingPlayer(guildID)
+	if p == nil {
+		return errNothingPlaying
+	}
+
+	b.GetOrCreatePlayer(guildID).SetVolume(level)
+	return p.Update(context.TODO(), lavalink.WithVolume(level))
+}
+
 func (b *Bot) handleRepeat(event *events.ApplicationCommandInteractionCreate) {
 	data := event.SlashCommandInteractionData()
 	mode := data.String("mode")
 
-	gp := b.GetOrCreatePlayer(*event.GuildID())
-	gp.Mu.Lock()
+	repeatMode := player.RepeatOff
 	switch mode {
 	case "one":
-		gp.Repeat = player.RepeatOne
+		repeatMode = player.RepeatOne
 	case "all":
-		gp.Repeat = player.RepeatAll
-	default:
-		gp.Repeat = player.RepeatOff
+		repeatMode = player.RepeatAll
 	}
-	repeatMode := gp.Repeat
-	gp.Mu.Unlock()
+
+	gp := b.GetOrCreatePlayer(*event.GuildID())
+	gp.SetRepeat(repeatMode)
 
 	b.respondEphemeral(event, fmt.Sprintf("반복 모드: **%s**", repeatMode))
 	b.updateNowPlayingEmbed(*event.GuildID())
 }
 
+func (b *Bot) handleQueueMode(event *events.ApplicationCommandInteractionCreate) {
+	data := event.SlashCommandInteractionData()
+
+	mode := player.QueueFIFO
+	if data.String("mode") == "fair" {
+		mode = player.QueueFair
+	}
+
+	gp := b.GetOrCreatePlayer(*event.GuildID())
+	gp.SetQueueMode(mode)
+	b.respondEphemeral(event, fmt.Sprintf("대기열 모드: **%s**", mode))
+}
+
+func (b *Bot) handleSource(event *events.ApplicationCommandInteractionCreate) {
+	source, ok := search.FindSource(event.SlashCommandInteractionData().String("source"))
+	if !ok {
+		b.respondEphemeral(event, "알 수 없는 검색 소스입니다.")
+		return
+	}
+	if _, err := b.Settings.Update(*event.GuildID(), func(g *settings.Guild) {
+		g.SearchSource = source.Name
+	}); err != nil {
+		b.respondEphemeral(event, "설정 저장 실패: "+err.Error())
+		return
+	}
+
+	content := fmt.Sprintf("기본 검색 소스를 **%s**(으)로 설정했습니다.", source)
+	if source.Plugin {
+		content += "\nLavalink 서버에 해당 플러그인(LavaSrc 등)이 설치되어 있어야 합니다."
+	}
+	b.respondEphemeral(event, content)
+}
+
 func (b *Bot) handleShuffle(event *events.ApplicationCommandInteractionCreate) {
 	gp := b.GetOrCreatePlayer(*event.GuildID())
 	if gp.QueueLen() == 0 {
@@ -446,19 +699,57 @@ func (b *Bot) handleNowPlaying(event *events.ApplicationCommandInteractionCreate
 		Build())
 }
 
+func (b *Bot) handleHistory(event *events.ApplicationCommandInteractionCreate) {
+	gp := b.GetOrCreatePlayer(*event.GuildID())
+	e, components := embed.HistoryMessage(gp, 0)
+
+	_ = event.CreateMessage(discord.NewMessageCreateBuilder().
+		AddEmbeds(e).
+		AddContainerComponents(components...).
+		SetEphemeral(true).
+		Build())
+}
+
+func (b *Bot) handleHistoryButton(event *events.ComponentInteractionCreate, customID string) {
+	action, pageStr, _ := strings.Cut(customID, ":")
+	page, err := strconv.Atoi(pageStr)
+	if err != nil {
+		return
+	}
+
+	switch action {
+	case "history_prev":
+		page--
+	case "history_next":
+		page++
+	}
+
+	gp := b.GetOrCreatePlayer(*event.GuildID())
+	e, components := embed.HistoryMessage(gp, page)
+	_ = event.UpdateMessage(discord.NewMessageUpdateBuilder().
+		SetEmbeds(e).
+		SetContainerComponents(components...).
+		Build())
+}
+
 func (b *Bot) handleNPButton(event *events.ComponentInteractionCreate, customID string) {
 	guildID := *event.GuildID()
 	gp := b.GetOrCreatePlayer(guildID)
 
+	gp.Mu.Lock()
+	current := gp.CurrentTrack
+	gp.Mu.Unlock()
+	if !b.authorize(guildID, event.Member(), customID, current) {
+		_ = event.CreateMessage(discord.NewMessageCreateBuilder().
+			SetContent(permissionDenied).
+			SetEphemeral(true).
+			Build())
+		return
+	}
+
 	switch customID {
 	case "np_voldown":
-		gp.Mu.Lock()
-		gp.Volume -= 10
-		if gp.Volume < 0 {
-			gp.Volume = 0
-		}
-		newVol := gp.Volume
-		gp.Mu.Unlock()
+		newVol := gp.AdjustVolume(-10)
 
 		if p := b.Lavalink.ExistingPlayer(guildID); p != nil {
 			_ = p.Update(context.TODO(), lavalink.WithVolume(newVol))
@@ -466,13 +757,7 @@ func (b *Bot) handleNPButton(event *events.ComponentInteractionCreate, customID
 		b.updateNPMessage(event, guildID)
 
 	case "np_volup":
-		gp.Mu.Lock()
-		gp.Volume += 10
-		if gp.Volume > 100 {
-			gp.Volume = 100
-		}
-		newVol := gp.Volume
-		gp.Mu.Unlock()
+		newVol := gp.AdjustVolume(10)
 
 		if p := b.Lavalink.ExistingPlayer(guildID); p != nil {
 			_ = p.Update(context.TODO(), lavalink.WithVolume(newVol))
@@ -486,31 +771,75 @@ func (b *Bot) handleNPButton(event *events.ComponentInteractionCreate, customID
 			return
 		}
 
-		nextTrack := gp.Next()
-		if nextTrack == nil {
-			_ = p.Update(context.TODO(), lavalink.WithNullTrack())
+		skip, votes, needed, err := b.skipVote(guildID, event.Member(), gp)
+		if err != nil || !skip {
+			content := fmt.Sprintf("스킵에 투표했습니다. (%d/%d)", votes, needed)
+			if err != nil {
+				content = err.Error()
+			}
+			_ = event.CreateMessage(discord.NewMessageCreateBuilder().
+				SetContent(content).
+				SetEphemeral(true).
+				Build())
+			b.updateNowPlayingEmbed(guildID)
+			return
+		}
+
+		_, _ = b.skipTrack(p, gp)
+		_ = event.DeferUpdateMessage()
+
+	case "np_previous":
+		p := b.Lavalink.ExistingPlayer(guildID)
+		if p == nil {
 			_ = event.DeferUpdateMessage()
 			return
 		}
 
-		_ = p.Update(context.TODO(), lavalink.WithTrack(*nextTrack))
+		if prevTrack := gp.Previous(); prevTrack != nil {
+			_ = p.Update(context.TODO(), lavalink.WithTrack(*prevTrack))
+		}
 		_ = event.DeferUpdateMessage()
 
+	case "np_rewind", "np_forward":
+		input := "-10"
+		if customID == "np_forward" {
+			input = "+10"
+		}
+		if _, err := b.seekTo(guildID, input); err != nil {
+			_ = event.CreateMessage(discord.NewMessageCreateBuilder().
+				SetContent("탐색 실패: " + err.Error()).
+				SetEphemeral(true).
+				Build())
+			return
+		}
+		b.updateNPMessage(event, guildID)
+
 	case "np_repeat":
 		newMode := gp.NextRepeat()
 		_ = newMode
 		b.updateNPMessage(event, guildID)
 
 	case "np_queue":
-		e := embed.QueueEmbed(gp)
+		e, components := b.queueMessage(gp, 0)
 		_ = event.CreateMessage(discord.NewMessageCreateBuilder().
 			AddEmbeds(e).
+			AddContainerComponents(components...).
 			SetEphemeral(true).
 			Build())
 	}
 }
 
 func (b *Bot) updateNPMessage(event *events.ComponentInteractionCreate, guildID snowflake.ID) {
+	// 신청 채널 패널의 버튼이면 패널 전체를 다시 그림
+	if event.Message.ID == b.Settings.Get(guildID).RequestPanelID {
+		embeds, components := b.panelMessage(guildID)
+		_ = event.UpdateMessage(discord.NewMessageUpdateBuilder().
+			SetEmbeds(embeds...).
+			SetContainerComponents(components...).
+			Build())
+		return
+	}
+
 	gp := b.GetOrCreatePlayer(guildID)
 	p := b.Lavalink.ExistingPlayer(guildID)
 
@@ -539,6 +868,13 @@ func (b *Bot) handleHelp(event *events.ApplicationCommandInteractionCreate) {
 		Build())
 }
 
+// responder는 지연 응답한 명령어의 응답을 content로 바꾸는 함수를 반환합니다.
+func (b *Bot) responder(event *events.ApplicationCommandInteractionCreate) func(content string) {
+	return func(content string) {
+		b.updateResponse(event, content)
+	}
+}
+
 func (b *Bot) updateResponse(event *events.ApplicationCommandInteractionCreate, content string) {
 	_, err := b.Client.Rest().UpdateInteractionResponse(event.ApplicationID(), event.Token(), discord.NewMessageUpdateBuilder().
 		SetContent(content).
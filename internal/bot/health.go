@@ -0,0 +1,75 @@
+package bot
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/disgo/gateway"
+	"github.com/disgoorg/disgolink/v3/disgolink"
+	"github.com/uzih05/discord-music-bot/internal/api"
+)
+
+// gatewayGracePeriod 동안은 게이트웨이가 끊겨도 disgo의 자동 재연결을 기다리고 /healthz를 실패시키지 않습니다.
+const gatewayGracePeriod = 2 * time.Minute
+
+// health는 /healthz, /readyz 상태를 계산합니다.
+// Lavalink 노드는 백그라운드에서 다시 연결되고 봇을 재시작해도 나아지지 않으므로 준비 상태에만 반영합니다.
+func (b *Bot) health() api.Health {
+	now := time.Now()
+	gw := b.Client.Gateway()
+	status := gw.Status()
+
+	// 끊긴 시점은 상태를 확인할 때 기록하므로 최대 확인 주기만큼 늦게 잡힐 수 있음
+	b.healthMu.Lock()
+	if status == gateway.StatusReady {
+		b.gatewayDownSince = time.Time{}
+	} else if b.gatewayDownSince.IsZero() {
+		b.gatewayDownSince = now
+	}
+	downSince := b.gatewayDownSince
+	b.healthMu.Unlock()
+
+	h := api.Health{
+		Gateway: api.GatewayHealth{
+			Status:  status.String(),
+			Latency: gw.Latency().Milliseconds(),
+		},
+		Nodes:              []api.NodeHealth{},
+		CommandsRegistered: b.commandsRegistered.Load(),
+		ShuttingDown:       b.closing.Load(),
+	}
+	if !downSince.IsZero() {
+		h.Gateway.DownFor = now.Sub(downSince).Milliseconds()
+	}
+
+	connected := 0
+	b.Lavalink.ForNodes(func(node disgolink.Node) {
+		if node.Status() == disgolink.StatusConnected {
+			connected++
+		}
+		h.Nodes = append(h.Nodes, api.NodeHealth{
+			Name:    node.Config().Name,
+			Status:  string(node.Status()),
+			Players: node.Stats().Players,
+		})
+	})
+	slices.SortFunc(h.Nodes, func(a, b api.NodeHealth) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	h.Live = downSince.IsZero() || now.Sub(downSince) < gatewayGracePeriod
+	h.Ready = status == gateway.StatusReady && connected > 0 && h.CommandsRegistered && !h.ShuttingDown
+	return h
+}
+
+// newHealthServer는 HEALTH_ADDR이 설정되어 있을 때만 /healthz, /readyz 전용 서버를 만듭니다.
+// 인증이 없으므로 127.0.0.1:8081처럼 외부에서 닿지 않는 주소를 권장합니다.
+func newHealthServer(b *Bot) *api.HealthServer {
+	addr := os.Getenv("HEALTH_ADDR")
+	if addr == "" {
+		return nil
+	}
+	return api.NewHealthServer(addr, b.health)
+}
@@ -0,0 +1,224 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgolink/v3/disgolink"
+	"github.com/disgoorg/disgolink/v3/lavalink"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/uzih05/discord-music-bot/internal/embed"
+	"github.com/uzih05/discord-music-bot/internal/lyrics"
+)
+
+// lyricsViewTTL은 가사 메시지를 갱신할 수 있는 시간입니다.
+// 인터랙션 토큰이 15분 동안만 유효하므로 그 전에 갱신을 멈춥니다.
+const lyricsViewTTL = 14 * time.Minute
+
+// lyricsView는 /lyrics로 보낸 가사 메시지입니다.
+// live가 true면 Now Playing 갱신 주기에 맞춰 현재 줄을 강조해 다시 그립니다.
+type lyricsView struct {
+	guildID   snowflake.ID
+	track     lavalink.Track
+	lyrics    *lyrics.Lyrics
+	token     string
+	live      bool
+	createdAt time.Time
+}
+
+// newLyricsProvider는 LYRICS_PROVIDER 환경변수(lavalyrics, file)로 가사 제공자를 고릅니다.
+func newLyricsProvider(b *Bot) lyrics.Provider {
+	if os.Getenv("LYRICS_PROVIDER") == "file" {
+		dir := os.Getenv("LYRICS_DIR")
+		if dir == "" {
+			dir = "lyrics"
+		}
+		return &lyrics.FileProvider{Dir: dir}
+	}
+	return &lyrics.LavaLyrics{Node: func() disgolink.Node {
+		return b.bestNode(0, "")
+	}}
+}
+
+func (b *Bot) handleLyrics(event *events.ApplicationCommandInteractionCreate) {
+	query := strings.TrimSpace(event.SlashCommandInteractionData().String("query"))
+	guildID := *event.GuildID()
+	gp := b.GetOrCreatePlayer(guildID)
+
+	_ = event.DeferCreateMessage(true)
+	ctx := context.TODO()
+
+	var track lavalink.Track
+	if query == "" {
+		gp.Mu.Lock()
+		current := gp.CurrentTrack
+		gp.Mu.Unlock()
+		if current == nil {
+			b.updateResponse(event, "재생 중인 곡이 없습니다. 검색어를 입력하세요.")
+			return
+		}
+		track = *current
+	} else {
+		node := b.bestNode(guildID, "")
+		if node == nil {
+			b.updateResponse(event, "사용 가능한 Lavalink 노드가 없습니다.")
+			return
+		}
+		searchQuery := query
+		if !urlPattern.MatchString(query) {
+			searchQuery = b.searchSource(guildID, "").Query(query)
+		}
+		var found []lavalink.Track
+		b.loadTracks(ctx, node, searchQuery, disgolink.NewResultHandler(
+			func(t lavalink.Track) { found = []lavalink.Track{t} },
+			func(playlist lavalink.Playlist) { found = playlist.Tracks },
+			func(tracks []lavalink.Track) { found = tracks },
+			func() {},
+			func(err error) { slog.Error("트랙 로딩 실패", "error", err) },
+		))
+		if len(found) == 0 {
+			b.updateResponse(event, "검색 결과가 없습니다.")
+			return
+		}
+		track = found[0]
+	}
+
+	l, err := b.Lyrics.Lyrics(ctx, track)
+	if errors.Is(err, lyrics.ErrNotFound) {
+		b.updateResponse(event, "**"+track.Info.Title+"**의 가사를 찾을 수 없습니다.")
+		return
+	}
+	if err != nil {
+		slog.Error("가사 가져오기 실패", "track", track.Info.Title, "error", err)
+		b.updateResponse(event, "가사 가져오기 실패: "+err.Error())
+		return
+	}
+
+	view := &lyricsView{
+		guildID:   guildID,
+		track:     track,
+		lyrics:    l,
+		token:     event.Token(),
+		live:      query == "" && l.Synced(),
+		createdAt: time.Now(),
+	}
+
+	e, components := b.renderLyrics(view, view.live, 0)
+	msg, err := b.Client.Rest().UpdateInteractionResponse(event.ApplicationID(), event.Token(), discord.NewMessageUpdateBuilder().
+		SetEmbeds(e).
+		SetContainerComponents(components...).
+		Build())
+	if err != nil {
+		slog.Error("가사 전송 실패", "error", err)
+		return
+	}
+
+	b.mu.Lock()
+	now := time.Now()
+	for id, v := range b.lyricsViews {
+		if now.Sub(v.createdAt) > lyricsViewTTL {
+			delete(b.lyricsViews, id)
+		}
+	}
+	b.lyricsViews[msg.ID] = view
+	b.mu.Unlock()
+}
+
+// renderLyrics는 실시간 가사면 현재 재생 위치 기준으로, 아니면 page번째 페이지를 그립니다.
+func (b *Bot) renderLyrics(view *lyricsView, live bool, page int) (discord.Embed, []discord.ContainerComponent) {
+	if live {
+		var position lavalink.Duration
+		if p := b.Lavalink.ExistingPlayer(view.guildID); p != nil {
+			position = p.Position()
+		}
+		return embed.LiveLyricsMessage(view.track, view.lyrics, position)
+	}
+	return embed.LyricsMessage(view.track, view.lyrics, page)
+}
+
+func (b *Bot) handleLyricsButton(event *events.ComponentInteractionCreate, customID string) {
+	action, pageStr, _ := strings.Cut(customID, ":")
+	page, err := strconv.Atoi(pageStr)
+	if err != nil {
+		return
+	}
+
+	b.mu.Lock()
+	view := b.lyricsViews[event.Message.ID]
+	if view != nil {
+		// 페이지를 넘기면 실시간 갱신을 멈춤
+		view.live = false
+	}
+	b.mu.Unlock()
+	if view == nil {
+		_ = event.CreateMessage(discord.NewMessageCreateBuilder().
+			SetContent("가사 세션이 만료되었습니다. 다시 /lyrics를 사용해주세요.").
+			SetEphemeral(true).
+			Build())
+		return
+	}
+
+	switch action {
+	case "lyrics_prev":
+		page--
+	case "lyrics_next":
+		page++
+	}
+
+	e, components := b.renderLyrics(view, false, page)
+	_ = event.UpdateMessage(discord.NewMessageUpdateBuilder().
+		SetEmbeds(e).
+		SetContainerComponents(components...).
+		Build())
+}
+
+// updateLyricsViews는 길드의 실시간 가사 메시지를 현재 재생 위치로 갱신합니다.
+// 곡이 바뀌었거나 토큰이 만료될 때가 되면 일반 가사 페이지로 바꾸고 갱신을 멈추며, lyricsViewTTL이 지난 메시지는 지웁니다.
+func (b *Bot) updateLyricsViews(guildID snowflake.ID) {
+	gp := b.GetOrCreatePlayer(guildID)
+	gp.Mu.Lock()
+	current := gp.CurrentTrack
+	gp.Mu.Unlock()
+
+	type update struct {
+		messageID snowflake.ID
+		view      *lyricsView
+		live      bool
+	}
+	var updates []update
+
+	b.mu.Lock()
+	now := time.Now()
+	for id, view := range b.lyricsViews {
+		// 만료된 메시지는 길드와 관계없이 여기서 정리 (실시간이던 메시지는 아래에서 마지막으로 한 번 갱신)
+		expired := now.Sub(view.createdAt) > lyricsViewTTL
+		if expired {
+			delete(b.lyricsViews, id)
+		}
+		if view.guildID != guildID || !view.live {
+			continue
+		}
+		if expired || current == nil || current.Encoded != view.track.Encoded {
+			view.live = false
+		}
+		updates = append(updates, update{messageID: id, view: view, live: view.live})
+	}
+	b.mu.Unlock()
+
+	for _, u := range updates {
+		e, components := b.renderLyrics(u.view, u.live, 0)
+		if _, err := b.Client.Rest().UpdateInteractionResponse(b.Client.ApplicationID(), u.view.token, discord.NewMessageUpdateBuilder().
+			SetEmbeds(e).
+			SetContainerComponents(components...).
+			Build()); err != nil {
+			slog.Debug("실시간 가사 갱신 실패", "guild", guildID, "message", u.messageID, "error", err)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/disgoorg/disgo"
+	"github.com/disgoorg/disgo/rest"
+	"github.com/disgoorg/disgolink/v3/disgolink"
+	"github.com/disgoorg/disgolink/v3/lavalink"
+	"github.com/uzih05/discord-music-bot/internal/metrics"
+	"github.com/uzih05/discord-music-bot/internal/player"
+)
+
+// botMetrics는 API 서버의 /metrics로 내보내는 봇 메트릭입니다.
+// 길드 ID처럼 개수가 끝없이 늘어나는 값은 레이블로 쓰지 않습니다.
+type botMetrics struct {
+	registry *metrics.Registry
+
+	commands       *metrics.Counter
+	tracksPlayed   *metrics.Counter
+	loadFailures   *metrics.Counter
+	exceptions     *metrics.Counter
+	stuck          *metrics.Counter
+	loadDuration   *metrics.Histogram
+	restDuration   *metrics.Histogram
+	activePlayers  *metrics.Gauge
+	queuedTracks   *metrics.Gauge
+	voiceChannels  *metrics.Gauge
+	nodeUp         *metrics.Gauge
+	nodePlayers    *metrics.Gauge
+	nodePlaying    *metrics.Gauge
+	nodeSystemLoad *metrics.Gauge
+	nodeLoad       *metrics.Gauge
+	nodeMemory     *metrics.Gauge
+	nodeFrames     *metrics.Gauge
+}
+
+func newBotMetrics() *botMetrics {
+	r := metrics.NewRegistry()
+	return &botMetrics{
+		registry:       r,
+		commands:       r.Counter("musicbot_commands_total", "실행된 슬래시 명령어 수", "command"),
+		tracksPlayed:   r.Counter("musicbot_tracks_played_total", "재생을 시작한 곡 수", "source"),
+		loadFailures:   r.Counter("musicbot_track_load_failures_total", "곡 검색/로딩 실패 수 (type: no_matches, common, suspicious, fault, request)", "type"),
+		exceptions:     r.Counter("musicbot_track_exceptions_total", "재생 중 발생한 트랙 예외 수", "severity"),
+		stuck:          r.Counter("musicbot_track_stuck_total", "재생이 멈춘 트랙 수"),
+		loadDuration:   r.Histogram("musicbot_track_load_duration_seconds", "Lavalink 곡 로딩(LoadTracks) 시간", []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}, "result"),
+		restDuration:   r.Histogram("musicbot_discord_rest_duration_seconds", "Discord REST 요청 시간 (레이트 리밋 대기 포함)", metrics.DefaultBuckets, "method", "route", "status"),
+		activePlayers:  r.Gauge("musicbot_active_players", "곡을 재생 중인 길드 수"),
+		queuedTracks:   r.Gauge("musicbot_queued_tracks", "모든 길드의 대기열 곡 수 합계"),
+		voiceChannels:  r.Gauge("musicbot_voice_connections", "봇이 접속한 음성 채널 수"),
+		nodeUp:         r.Gauge("musicbot_lavalink_node_up", "Lavalink 노드 연결 여부 (1: 연결됨)", "node"),
+		nodePlayers:    r.Gauge("musicbot_lavalink_node_players", "노드의 플레이어 수", "node"),
+		nodePlaying:    r.Gauge("musicbot_lavalink_node_playing_players", "노드에서 재생 중인 플레이어 수", "node"),
+		nodeSystemLoad: r.Gauge("musicbot_lavalink_node_cpu_system_load", "노드 서버의 CPU 사용률 (0~1)", "node"),
+		nodeLoad:       r.Gauge("musicbot_lavalink_node_cpu_lavalink_load", "Lavalink 프로세스의 CPU 사용률 (0~1)", "node"),
+		nodeMemory:     r.Gauge("musicbot_lavalink_node_memory_bytes", "노드 메모리 (state: used, free, allocated, reservable)", "node", "state"),
+		nodeFrames:     r.Gauge("musicbot_lavalink_node_frames", "최근 1분간 오디오 프레임 수 (state: sent, nulled, deficit)", "node", "state"),
+	}
+}
+
+// collectMetrics는 스크랩할 때마다 플레이어와 노드 상태로 게이지를 채웁니다.
+// 노드 값은 Lavalink가 주기적으로 보내는 stats 메시지 중 마지막 것입니다.
+func (b *Bot) collectMetrics() {
+	m := b.metrics
+
+	b.mu.Lock()
+	players := make([]*player.GuildPlayer, 0, len(b.Players))
+	for _, gp := range b.Players {
+		players = append(players, gp)
+	}
+	b.mu.Unlock()
+
+	active, voice, queued := 0, 0, 0
+	for _, gp := range players {
+		gp.Mu.Lock()
+		playing := gp.CurrentTrack != nil
+		queued += len(gp.Queue)
+		gp.Mu.Unlock()
+
+		if playing {
+			active++
+		}
+		if vs, ok := b.Client.Caches().VoiceState(gp.GuildID, b.Client.ApplicationID()); ok && vs.ChannelID != nil {
+			voice++
+		}
+	}
+	m.activePlayers.Set(float64(active))
+	m.queuedTracks.Set(float64(queued))
+	m.voiceChannels.Set(float64(voice))
+
+	for _, g := range []*metrics.Gauge{m.nodeUp, m.nodePlayers, m.nodePlaying, m.nodeSystemLoad, m.nodeLoad, m.nodeMemory, m.nodeFrames} {
+		g.Reset()
+	}
+	b.Lavalink.ForNodes(func(node disgolink.Node) {
+		name := node.Config().Name
+		if node.Status() != disgolink.StatusConnected {
+			m.nodeUp.Set(0, name)
+			return
+		}
+		m.nodeUp.Set(1, name)
+
+		stats := node.Stats()
+		m.nodePlayers.Set(float64(stats.Players), name)
+		m.nodePlaying.Set(float64(stats.PlayingPlayers), name)
+		m.nodeSystemLoad.Set(stats.CPU.SystemLoad, name)
+		m.nodeLoad.Set(stats.CPU.LavalinkLoad, name)
+		m.nodeMemory.Set(float64(stats.Memory.Used), name, "used")
+		m.nodeMemory.Set(float64(stats.Memory.Free), name, "free")
+		m.nodeMemory.Set(float64(stats.Memory.Allocated), name, "allocated")
+		m.nodeMemory.Set(float64(stats.Memory.Reservable), name, "reservable")
+		// 재생 중인 플레이어가 없으면 Lavalink가 frameStats를 보내지 않음
+		if stats.FrameStats != nil {
+			m.nodeFrames.Set(float64(stats.FrameStats.Sent), name, "sent")
+			m.nodeFrames.Set(float64(stats.FrameStats.Nulled), name, "nulled")
+			m.nodeFrames.Set(float64(stats.FrameStats.Deficit), name, "deficit")
+		}
+	})
+}
+
+// loadTracks는 node.LoadTracksHandler를 호출하면서 로딩 시간과 실패를 기록합니다.
+// 곡 로딩은 모두 이 함수를 거쳐야 메트릭에 잡힙니다.
+func (b *Bot) loadTracks(ctx context.Context, node disgolink.Node, identifier string, handler disgolink.AudioLoadResultHandler) {
+	node.LoadTracksHandler(ctx, identifier, &loadObserver{
+		metrics: b.metrics,
+		start:   time.Now(),
+		handler: handler,
+	})
+}
+
+// loadObserver는 결과 처리(재생 시작 등)에 걸린 시간이 섞이지 않도록 handler를 부르기 전에 기록합니다.
+type loadObserver struct {
+	metrics *botMetrics
+	start   time.Time
+	handler disgolink.AudioLoadResultHandler
+}
+
+var _ disgolink.AudioLoadResultHandler = (*loadObserver)(nil)
+
+func (o *loadObserver) observe(result string) {
+	o.metrics.loadDuration.Observe(time.Since(o.start).Seconds(), result)
+}
+
+func (o *loadObserver) TrackLoaded(track lavalink.Track) {
+	o.observe("track")
+	o.handler.TrackLoaded(track)
+}
+
+func (o *loadObserver) PlaylistLoaded(playlist lavalink.Playlist) {
+	o.observe("playlist")
+	o.handler.PlaylistLoaded(playlist)
+}
+
+func (o *loadObserver) SearchResultLoaded(tracks []lavalink.Track) {
+	o.observe("search")
+	o.handler.SearchResultLoaded(tracks)
+}
+
+func (o *loadObserver) NoMatches() {
+	o.observe("empty")
+	o.metrics.loadFailures.Inc("no_matches")
+	o.handler.NoMatches()
+}
+
+func (o *loadObserver) LoadFailed(err error) {
+	o.observe("error")
+	// Lavalink가 돌려준 예외는 심각도로, 요청 자체의 실패(연결, 타임아웃)는 request로 구분
+	failure := "request"
+	var exception lavalink.Exception
+	if errors.As(err, &exception) {
+		failure = string(exception.Severity)
+	}
+	o.metrics.loadFailures.Inc(failure)
+	o.handler.LoadFailed(err)
+}
+
+// newRestClient는 요청 시간을 기록하는 Discord REST 클라이언트를 만듭니다.
+func newRestClient(token string, m *botMetrics) rest.Client {
+	return &restObserver{
+		Client: rest.NewClient(token,
+			// disgo가 기본 클라이언트에 붙이는 User-Agent와 같게 맞춤
+			rest.WithUserAgent(fmt.Sprintf("DiscordBot (%s, %s)", disgo.GitHub, disgo.Version)),
+		),
+		duration: m.restDuration,
+	}
+}
+
+type restObserver struct {
+	rest.Client
+	duration *metrics.Histogram
+}
+
+// Do는 레이블 수가 늘지 않도록 실제 URL 대신 {channel.id} 같은 자리표시자가 있는 경로로 기록합니다.
+func (c *restObserver) Do(endpoint *rest.CompiledEndpoint, rqBody any, rsBody any, opts ...rest.RequestOpt) error {
+	start := time.Now()
+	err := c.Client.Do(endpoint, rqBody, rsBody, opts...)
+	c.duration.Observe(time.Since(start).Seconds(), endpoint.Endpoint.Method, endpoint.Endpoint.Route, restStatus(err))
+	return err
+}
+
+func restStatus(err error) string {
+	if err == nil {
+		return "2xx"
+	}
+	var restErr rest.Error
+	if errors.As(err, &restErr) && restErr.Response != nil {
+		return strconv.Itoa(restErr.Response.StatusCode)
+	}
+	return "error"
+}
@@ -0,0 +1,277 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/disgolink/v3/disgolink"
+	"github.com/disgoorg/disgolink/v3/lavalink"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/uzih05/discord-music-bot/internal/player"
+)
+
+const nodeRetryInterval = 30 * time.Second
+
+// NodeConfig는 LAVALINK_NODES 환경변수(JSON 배열)의 항목입니다.
+// Region은 Discord 음성 서버 엔드포인트(예: c-icn01-xxxx.discord.media)에
+// 포함된 문자열과 비교해 가까운 노드를 우선 선택하는 데 사용됩니다.
+type NodeConfig struct {
+	Name     string `json:"name"`
+	Address  string `json:"address"`
+	Password string `json:"password"`
+	Secure   bool   `json:"secure"`
+	Region   string `json:"region"`
+}
+
+func loadNodeConfigs() ([]NodeConfig, error) {
+	if raw := os.Getenv("LAVALINK_NODES"); raw != "" {
+		var configs []NodeConfig
+		if err := json.Unmarshal([]byte(raw), &configs); err != nil {
+			return nil, fmt.Errorf("LAVALINK_NODES 파싱 실패: %w", err)
+		}
+		if len(configs) == 0 {
+			return nil, errors.New("LAVALINK_NODES에 노드가 없습니다")
+		}
+		for i, c := range configs {
+			if c.Name == "" || c.Address == "" {
+				return nil, fmt.Errorf("LAVALINK_NODES[%d]: name과 address는 필수입니다", i)
+			}
+		}
+		return configs, nil
+	}
+
+	// 단일 노드 설정 (기존 환경변수)
+	host := os.Getenv("LAVALINK_HOST")
+	if host == "" {
+		host = "localhost"
+	}
+	port := os.Getenv("LAVALINK_PORT")
+	if port == "" {
+		port = "2333"
+	}
+	password := os.Getenv("LAVALINK_PASSWORD")
+	if password == "" {
+		password = "youshallnotpass"
+	}
+	return []NodeConfig{{
+		Name:     "main",
+		Address:  host + ":" + port,
+		Password: password,
+		Secure:   os.Getenv("LAVALINK_SECURE") == "true",
+	}}, nil
+}
+
+func (b *Bot) registerLavalinkNodes(ctx context.Context) error {
+	configs, err := loadNodeConfigs()
+	if err != nil {
+		return err
+	}
+
+	connected := 0
+	for _, c := range configs {
+		b.nodeRegions[c.Name] = c.Region
+		if err := b.addNode(ctx, c); err != nil {
+			slog.Error("Lavalink 노드 연결 실패, 백그라운드에서 재시도합니다", "name", c.Name, "error", err)
+			go b.retryNode(c)
+			continue
+		}
+		connected++
+	}
+
+	if connected == 0 {
+		return errors.New("연결된 Lavalink 노드가 없습니다")
+	}
+	return nil
+}
+
+func (b *Bot) addNode(ctx context.Context, c NodeConfig) error {
+	node, err := b.Lavalink.AddNode(ctx, disgolink.NodeConfig{
+		Name:     c.Name,
+		Address:  c.Address,
+		Password: c.Password,
+		Secure:   c.Secure,
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Lavalink 노드 연결 완료", "name", node.Config().Name, "region", c.Region)
+	// AddNode가 노드를 등록하기 전에 OnNodeOpen이 불릴 수 있어 bestNode가 새 노드를 못 볼 수 있으므로
+	// 처음 연결된 노드로의 이동은 등록이 끝난 여기서 시작
+	go b.migrateStranded()
+	return nil
+}
+
+func (b *Bot) retryNode(c NodeConfig) {
+	for !b.closing.Load() {
+		time.Sleep(nodeRetryInterval)
+		if err := b.addNode(context.Background(), c); err != nil {
+			slog.Debug("Lavalink 노드 재연결 실패", "name", c.Name, "error", err)
+			continue
+		}
+		return
+	}
+}
+
+// bestNode는 연결된 노드 중 부하가 가장 낮은 노드를 고릅니다.
+// 길드 음성 서버와 같은 리전 태그를 가진 노드가 있으면 그쪽을 우선합니다.
+// disgolink의 BestNode()는 연결이 끊긴 노드도 후보에 넣기 때문에 직접 선택합니다.
+func (b *Bot) bestNode(guildID snowflake.ID, exclude string) disgolink.Node {
+	b.mu.Lock()
+	endpoint := b.voice[guildID].Endpoint
+	b.mu.Unlock()
+
+	var best, regional disgolink.Node
+	b.Lavalink.ForNodes(func(node disgolink.Node) {
+		name := node.Config().Name
+		if node.Status() != disgolink.StatusConnected || name == exclude {
+			return
+		}
+		if best == nil || nodeLoad(node) < nodeLoad(best) {
+			best = node
+		}
+		region := b.nodeRegions[name]
+		if region != "" && strings.Contains(endpoint, region) {
+			if regional == nil || nodeLoad(node) < nodeLoad(regional) {
+				regional = node
+			}
+		}
+	})
+
+	if regional != nil {
+		return regional
+	}
+	return best
+}
+
+func nodeLoad(node disgolink.Node) float64 {
+	stats := node.Stats()
+	load := float64(stats.PlayingPlayers)
+	if stats.CPU.Cores > 0 {
+		load += stats.CPU.SystemLoad / float64(stats.CPU.Cores) * 100
+	}
+	return load
+}
+
+// lavalinkPlayer는 길드의 Lavalink 플레이어를 반환하며, 없으면 가장 적합한 노드에 새로 만듭니다.
+func (b *Bot) lavalinkPlayer(ctx context.Context, gp *player.GuildPlayer) disgolink.Player {
+	p := b.Lavalink.ExistingPlayer(gp.GuildID)
+	if p == nil {
+		// 길드 기본 볼륨은 GuildPlayer를 만들 때만 적용하고, 여기서는 사용자가 바꾼 볼륨을 그대로 이어감
+		gp.Mu.Lock()
+		volume := gp.Volume
+		gp.Mu.Unlock()
+		p = b.Lavalink.PlayerOnNode(b.bestNode(gp.GuildID, ""), gp.GuildID)
+		_ = p.Update(ctx, lavalink.WithVolume(volume), lavalink.WithFilters(gp.FilterState().Build()))
+	}
+	return p
+}
+
+// nodeWatcher는 노드 연결 상태 변화를 받아 플레이어를 다른 노드로 옮깁니다.
+type nodeWatcher struct {
+	bot *Bot
+}
+
+var _ disgolink.PluginEventHandler = (*nodeWatcher)(nil)
+
+func (w *nodeWatcher) Name() string    { return "node-watcher" }
+func (w *nodeWatcher) Version() string { return "1.0.0" }
+
+func (w *nodeWatcher) OnNodeOpen(node disgolink.Node) {
+	if w.bot.closing.Load() {
+		return
+	}
+	// 아직 등록되지 않은 노드(AddNode 진행 중)는 addNode가 끝난 뒤 옮김
+	if w.bot.Lavalink.Node(node.Config().Name) == nil {
+		return
+	}
+	go w.bot.migrateStranded()
+}
+
+func (w *nodeWatcher) OnNodeClose(node disgolink.Node) {
+	if w.bot.closing.Load() {
+		return
+	}
+	name := node.Config().Name
+	slog.Warn("Lavalink 노드 연결 끊김, 플레이어를 이동합니다", "name", name)
+	go w.bot.migratePlayers(func(p disgolink.Player) bool {
+		return p.Node().Config().Name == name
+	}, name)
+}
+
+func (w *nodeWatcher) OnNodeMessageIn(disgolink.Node, []byte) {}
+func (w *nodeWatcher) OnNewPlayer(disgolink.Player)           {}
+func (w *nodeWatcher) OnDestroyPlayer(disgolink.Player)       {}
+
+// migrateStranded는 연결이 끊긴 노드에 남아 있던 플레이어를 정상 노드로 옮깁니다.
+func (b *Bot) migrateStranded() {
+	b.migratePlayers(func(p disgolink.Player) bool {
+		return p.Node().Status() != disgolink.StatusConnected
+	}, "")
+}
+
+// migratePlayers는 match에 해당하는 플레이어를 exclude가 아닌 정상 노드로 옮깁니다.
+// 노드 재연결과 addNode에서 동시에 불려도 같은 플레이어를 두 번 옮기지 않도록 한 번에 하나씩 실행합니다.
+func (b *Bot) migratePlayers(match func(p disgolink.Player) bool, exclude string) {
+	b.migrateMu.Lock()
+	defer b.migrateMu.Unlock()
+
+	var players []disgolink.Player
+	b.Lavalink.ForPlayers(func(p disgolink.Player) {
+		if match(p) {
+			players = append(players, p)
+		}
+	})
+
+	for _, p := range players {
+		target := b.bestNode(p.GuildID(), exclude)
+		if target == nil {
+			slog.Error("플레이어를 옮길 정상 노드가 없습니다", "guild", p.GuildID())
+			return
+		}
+		if err := b.migratePlayer(context.TODO(), p, target); err != nil {
+			slog.Error("플레이어 노드 이동 실패", "guild", p.GuildID(), "node", target.Config().Name, "error", err)
+			continue
+		}
+		slog.Info("플레이어 노드 이동 완료", "guild", p.GuildID(), "node", target.Config().Name)
+	}
+}
+
+// migratePlayer는 트랙, 위치, 볼륨, 일시정지, 필터 상태를 그대로 새 노드에 재현합니다.
+func (b *Bot) migratePlayer(ctx context.Context, old disgolink.Player, target disgolink.Node) error {
+	guildID := old.GuildID()
+	track := old.Track()
+	position := old.Position()
+	channelID := old.ChannelID()
+
+	b.mu.Lock()
+	voice, ok := b.voice[guildID]
+	b.mu.Unlock()
+	if !ok || channelID == nil {
+		return errors.New("음성 연결 정보가 없습니다")
+	}
+
+	// 이전 노드의 트랙 이벤트가 오지 않으므로 Now Playing 메시지를 먼저 정리
+	b.finishNowPlaying(b.GetOrCreatePlayer(guildID))
+
+	b.Lavalink.RemovePlayer(guildID)
+	p := b.Lavalink.PlayerOnNode(target, guildID)
+	p.OnVoiceStateUpdate(ctx, channelID, voice.SessionID)
+
+	opts := []lavalink.PlayerUpdateOpt{
+		lavalink.WithVoice(voice),
+		lavalink.WithVolume(old.Volume()),
+		lavalink.WithPaused(old.Paused()),
+		lavalink.WithFilters(old.Filters()),
+	}
+	if track != nil {
+		opts = append(opts, lavalink.WithTrack(*track), lavalink.WithPosition(position))
+	}
+	return p.Update(ctx, opts...)
+}
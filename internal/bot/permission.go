@@ -0,0 +1,147 @@
+package bot
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgolink/v3/lavalink"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/uzih05/discord-music-bot/internal/embed"
+	"github.com/uzih05/discord-music-bot/internal/permission"
+	"github.com/uzih05/discord-music-bot/internal/player"
+	"github.com/uzih05/discord-music-bot/internal/settings"
+)
+
+const permissionDenied = "이 기능을 사용할 권한이 없습니다. (DJ 역할 필요)"
+
+func toPermissionMember(guildID snowflake.ID, member *discord.ResolvedMember) permission.Member {
+	return permission.Member{
+		RoleIDs: append(slices.Clone(member.RoleIDs), guildID),
+		Admin: member.Permissions.Has(discord.PermissionAdministrator) ||
+			member.Permissions.Has(discord.PermissionManageGuild),
+	}
+}
+
+// authorize는 권한 매트릭스를 확인하고, 통과하지 못해도 다음 경우에는 허용합니다.
+//   - track을 신청한 본인이 스킵/삭제하는 경우
+//   - 봇과 단둘이 음성 채널에 있는 경우
+func (b *Bot) authorize(guildID snowflake.ID, member *discord.ResolvedMember, action string, track *lavalink.Track) bool {
+	if member == nil {
+		return false
+	}
+
+	g := b.Settings.Get(guildID)
+	if g.VoteSkip && (action == "skip" || action == "np_skip") {
+		// 투표 스킵 모드에서는 핸들러가 투표로 처리
+		return true
+	}
+	if permission.Allowed(g, action, toPermissionMember(guildID, member)) {
+		return true
+	}
+
+	if track != nil && permission.Exempt(action, player.Requester(*track), member.User.ID) {
+		return true
+	}
+
+	return b.aloneWithBot(guildID, member.User.ID)
+}
+
+// commandTrack은 신청자 예외를 판단할 대상 곡을 찾습니다.
+func (b *Bot) commandTrack(event *events.ApplicationCommandInteractionCreate) *lavalink.Track {
+	data := event.SlashCommandInteractionData()
+	gp := b.GetOrCreatePlayer(*event.GuildID())
+
+	switch data.CommandName() {
+	case "skip":
+		gp.Mu.Lock()
+		defer gp.Mu.Unlock()
+		return gp.CurrentTrack
+	case "remove":
+		if track, ok := gp.At(data.Int("position")); ok {
+			return &track
+		}
+	}
+	return nil
+}
+
+func (b *Bot) handleDJ(event *events.ApplicationCommandInteractionCreate) {
+	data := event.SlashCommandInteractionData()
+	guildID := *event.GuildID()
+	if data.SubCommandName == nil {
+		return
+	}
+
+	switch *data.SubCommandName {
+	case "role":
+		roleID, _ := data.OptSnowflake("role")
+		if _, err := b.Settings.Update(guildID, func(g *settings.Guild) {
+			g.DJRoleID = roleID
+		}); err != nil {
+			b.respondEphemeral(event, "설정 저장 실패: "+err.Error())
+			return
+		}
+		if roleID == 0 {
+			b.respondEphemeral(event, "DJ 역할을 해제했습니다. 이제 모든 멤버가 모든 기능을 사용할 수 있습니다.")
+			return
+		}
+		b.respondEphemeral(event, fmt.Sprintf("DJ 역할을 <@&%s>(으)로 설정했습니다.", roleID))
+
+	case "allow":
+		action := strings.TrimSpace(data.String("action"))
+		roleID := data.Snowflake("role")
+		if !permission.Configurable(action) {
+			b.respondEphemeral(event, fmt.Sprintf("`%s`은(는) 설정할 수 없는 명령어/버튼입니다.", action))
+			return
+		}
+		if _, err := b.Settings.Update(guildID, func(g *settings.Guild) {
+			if g.Permissions == nil {
+				g.Permissions = make(map[string][]snowflake.ID)
+			}
+			if !slices.Contains(g.Permissions[action], roleID) {
+				g.Permissions[action] = append(g.Permissions[action], roleID)
+			}
+		}); err != nil {
+			b.respondEphemeral(event, "설정 저장 실패: "+err.Error())
+			return
+		}
+		b.respondEphemeral(event, fmt.Sprintf("`%s`에 <@&%s> 역할을 허용했습니다.", action, roleID))
+
+	case "reset":
+		action := strings.TrimSpace(data.String("action"))
+		if _, err := b.Settings.Update(guildID, func(g *settings.Guild) {
+			delete(g.Permissions, action)
+		}); err != nil {
+			b.respondEphemeral(event, "설정 저장 실패: "+err.Error())
+			return
+		}
+		b.respondEphemeral(event, fmt.Sprintf("`%s` 권한을 기본값으로 되돌렸습니다.", action))
+
+	case "voteskip":
+		enabled := data.Bool("enabled")
+		percent, hasPercent := data.OptInt("percent")
+		g, err := b.Settings.Update(guildID, func(g *settings.Guild) {
+			g.VoteSkip = enabled
+			if hasPercent {
+				g.VoteSkipPercent = percent
+			}
+		})
+		if err != nil {
+			b.respondEphemeral(event, "설정 저장 실패: "+err.Error())
+			return
+		}
+		if !enabled {
+			b.respondEphemeral(event, "투표 스킵을 껐습니다.")
+			return
+		}
+		b.respondEphemeral(event, fmt.Sprintf("투표 스킵을 켰습니다. 청취자의 **%d%%** 이상이 투표하면 스킵합니다.", g.SkipPercent()))
+
+	case "show":
+		_ = event.CreateMessage(discord.NewMessageCreateBuilder().
+			AddEmbeds(embed.PermissionEmbed(b.Settings.Get(guildID))).
+			SetEphemeral(true).
+			Build())
+	}
+}
@@ -0,0 +1,222 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgolink/v3/lavalink"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/uzih05/discord-music-bot/internal/player"
+	"github.com/uzih05/discord-music-bot/internal/store"
+)
+
+const snapshotBucket = "players"
+
+// saveDebounce 동안 생긴 변경은 스냅샷 저장 한 번으로 모읍니다.
+const saveDebounce = time.Second
+
+// scheduleSave는 saveDebounce 뒤에 길드 재생 상태를 저장하도록 예약합니다.
+// 변경마다 호출하는 쪽(게이트웨이 이벤트, 버튼 등)에서 Lavalink 조회와 파일 쓰기를 하지 않도록 타이머에서 처리합니다.
+func (b *Bot) scheduleSave(guildID snowflake.ID) {
+	if b.closing.Load() {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.saveTimers[guildID]; ok {
+		return
+	}
+	b.saveTimers[guildID] = time.AfterFunc(saveDebounce, func() {
+		b.mu.Lock()
+		delete(b.saveTimers, guildID)
+		b.mu.Unlock()
+		b.savePlayer(b.GetOrCreatePlayer(guildID))
+	})
+}
+
+// savePlayer는 길드 재생 상태를 저장소에 기록합니다.
+// 종료 중에는 음성 채널을 나가며 비워지는 상태가 shutdownPlayer가 저장한 스냅샷을 덮어쓰지 않도록 건너뜁니다.
+// 스냅샷을 saveMu 안에서 만들므로 먼저 만든 스냅샷이 나중 것을 덮어쓰지 않습니다.
+func (b *Bot) savePlayer(gp *player.GuildPlayer) {
+	b.saveMu.Lock()
+	defer b.saveMu.Unlock()
+	if b.closing.Load() {
+		return
+	}
+	b.storeSnapshot(b.snapshotPlayer(gp))
+}
+
+// snapshotPlayer는 대기열과 함께 Lavalink 재생 위치, 현재 음성 채널을 담은 스냅샷을 만듭니다.
+func (b *Bot) snapshotPlayer(gp *player.GuildPlayer) player.Snapshot {
+	s := gp.Snapshot()
+	if s.Empty() {
+		return s
+	}
+
+	if p := b.Lavalink.ExistingPlayer(s.GuildID); p != nil {
+		s.Paused = p.Paused()
+		if t := p.Track(); t != nil && s.CurrentTrack != nil && t.Encoded == s.CurrentTrack.Encoded {
+			s.Position = p.Position()
+		}
+	}
+	if vs, ok := b.Client.Caches().VoiceState(s.GuildID, b.Client.ApplicationID()); ok && vs.ChannelID != nil {
+		s.VoiceChannelID = *vs.ChannelID
+	}
+	return s
+}
+
+// storeSnapshot은 스냅샷을 저장하며, 복원할 곡이 없으면 기존 스냅샷을 삭제합니다.
+func (b *Bot) storeSnapshot(s player.Snapshot) {
+	key := s.GuildID.String()
+	if s.Empty() {
+		if err := b.Store.Delete(snapshotBucket, key); err != nil {
+			slog.Error("스냅샷 삭제 실패", "guild", s.GuildID, "error", err)
+		}
+		return
+	}
+	if err := b.Store.Put(snapshotBucket, key, s); err != nil {
+		slog.Error("스냅샷 저장 실패", "guild", s.GuildID, "error", err)
+	}
+}
+
+// loadSnapshots는 저장된 스냅샷을 읽어 GuildReady 시점에 복원하도록 대기시킵니다.
+func (b *Bot) loadSnapshots() {
+	keys, err := b.Store.Keys(snapshotBucket)
+	if err != nil {
+		slog.Error("스냅샷 목록 조회 실패", "error", err)
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, key := range keys {
+		var s player.Snapshot
+		if err := b.Store.Get(snapshotBucket, key, &s); err != nil {
+			slog.Error("스냅샷 읽기 실패", "key", key, "error", err)
+			continue
+		}
+		if s.Empty() || s.VoiceChannelID == 0 {
+			_ = b.Store.Delete(snapshotBucket, key)
+			continue
+		}
+		b.pendingRestores[s.GuildID] = s
+	}
+
+	if len(b.pendingRestores) > 0 {
+		slog.Info("복원 대기 중인 길드", "count", len(b.pendingRestores))
+	}
+}
+
+func (b *Bot) onGuildReady(event *events.GuildReady) {
+	for _, member := range event.Guild.Members {
+		b.rememberMember(member)
+	}
+	// 봇이 꺼져 있는 동안 패널이 지워졌을 수 있으므로 확인
+	b.schedulePanelUpdate(event.GuildID)
+
+	b.mu.Lock()
+	s, ok := b.pendingRestores[event.GuildID]
+	delete(b.pendingRestores, event.GuildID)
+	b.mu.Unlock()
+
+	if !ok {
+		return
+	}
+	go b.restorePlayer(s)
+}
+
+// restorePlayer는 마지막 음성 채널에 다시 접속하고 저장된 위치부터 재생을 이어갑니다.
+func (b *Bot) restorePlayer(s player.Snapshot) {
+	ctx := context.TODO()
+	// 종료할 때 남긴 재시작 안내는 복원을 마치면 (실패해도) 지움
+	if s.NoticeMessageID != 0 {
+		defer func() {
+			_ = b.Client.Rest().DeleteMessage(s.NoticeChannelID, s.NoticeMessageID)
+		}()
+	}
+
+	current, queue, err := b.decodeSnapshot(ctx, s)
+	if err != nil {
+		slog.Error("스냅샷 트랙 디코딩 실패", "guild", s.GuildID, "error", err)
+		return
+	}
+
+	gp := b.GetOrCreatePlayer(s.GuildID)
+	gp.Restore(s, current, queue)
+
+	position := s.Position
+	if current == nil {
+		current = gp.Next()
+		position = 0
+	}
+	if current == nil {
+		return
+	}
+	if current.Info.IsStream {
+		position = 0
+	}
+
+	if err := b.Client.UpdateVoiceState(ctx, s.GuildID, &s.VoiceChannelID, false, false); err != nil {
+		slog.Error("음성 채널 재접속 실패", "guild", s.GuildID, "error", err)
+		return
+	}
+
+	p := b.lavalinkPlayer(ctx, gp)
+	if err := p.Update(ctx,
+		lavalink.WithTrack(*current),
+		lavalink.WithPosition(position),
+		lavalink.WithVolume(s.Volume),
+		lavalink.WithPaused(s.Paused),
+	); err != nil {
+		slog.Error("재생 복원 실패", "guild", s.GuildID, "error", err)
+		return
+	}
+
+	slog.Info("재생 상태 복원 완료", "guild", s.GuildID, "track", current.Info.Title, "queue", len(queue))
+}
+
+func (b *Bot) decodeSnapshot(ctx context.Context, s player.Snapshot) (*lavalink.Track, []lavalink.Track, error) {
+	saved := s.Queue
+	if s.CurrentTrack != nil {
+		saved = append([]player.SavedTrack{*s.CurrentTrack}, saved...)
+	}
+
+	encoded := make([]string, len(saved))
+	for i, t := range saved {
+		encoded[i] = t.Encoded
+	}
+
+	node := b.bestNode(s.GuildID, "")
+	if node == nil {
+		return nil, nil, errors.New("사용 가능한 Lavalink 노드가 없습니다")
+	}
+	tracks, err := node.DecodeTracks(ctx, encoded)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(tracks) != len(saved) {
+		return nil, nil, errors.New("디코딩된 트랙 수가 일치하지 않습니다")
+	}
+	for i := range tracks {
+		tracks[i].UserData = saved[i].UserData
+	}
+
+	if s.CurrentTrack == nil {
+		return nil, tracks, nil
+	}
+	current := tracks[0]
+	return &current, tracks[1:], nil
+}
+
+func newStore() (store.Store, error) {
+	dir := os.Getenv("DATA_DIR")
+	if dir == "" {
+		dir = "data"
+	}
+	return store.NewFileStore(dir)
+}
@@ -0,0 +1,243 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgolink/v3/disgolink"
+	"github.com/disgoorg/disgolink/v3/lavalink"
+	"github.com/uzih05/discord-music-bot/internal/embed"
+	"github.com/uzih05/discord-music-bot/internal/playlist"
+)
+
+var errNotOwner = errors.New("서버 플레이리스트는 만든 사람이나 서버 관리자만 수정할 수 있습니다")
+
+func playlistScope(event *events.ApplicationCommandInteractionCreate) playlist.Scope {
+	if event.SlashCommandInteractionData().String("scope") == "guild" {
+		return playlist.GuildScope(*event.GuildID())
+	}
+	return playlist.UserScope(event.User().ID)
+}
+
+// canModify는 서버 플레이리스트를 만든 사람이거나 서버 관리자인지 확인합니다.
+// 개인 플레이리스트는 본인 것만 조회되므로 항상 수정할 수 있습니다.
+func canModify(event *events.ApplicationCommandInteractionCreate, scope playlist.Scope, p playlist.Playlist) bool {
+	if !scope.Guild || p.OwnerID == event.User().ID {
+		return true
+	}
+	member := event.Member()
+	return member != nil && toPermissionMember(*event.GuildID(), member).Admin
+}
+
+// updatePlaylist는 소유권을 확인한 뒤 플레이리스트를 수정합니다.
+func (b *Bot) updatePlaylist(event *events.ApplicationCommandInteractionCreate, scope playlist.Scope, name string, fn func(p *playlist.Playlist) error) (playlist.Playlist, error) {
+	return b.Playlists.Update(scope, name, func(p *playlist.Playlist) error {
+		if !canModify(event, scope, *p) {
+			return errNotOwner
+		}
+		return fn(p)
+	})
+}
+
+func (b *Bot) handlePlaylistCommand(event *events.ApplicationCommandInteractionCreate) {
+	data := event.SlashCommandInteractionData()
+	if data.SubCommandName == nil {
+		return
+	}
+	scope := playlistScope(event)
+	name := data.String("name")
+
+	switch *data.SubCommandName {
+	case "save":
+		gp := b.GetOrCreatePlayer(*event.GuildID())
+		gp.Mu.Lock()
+		var tracks []lavalink.Track
+		if gp.CurrentTrack != nil {
+			tracks = append(tracks, *gp.CurrentTrack)
+		}
+		tracks = append(tracks, gp.Queue...)
+		gp.Mu.Unlock()
+
+		err := b.Playlists.Create(scope, playlist.Playlist{
+			Name:    name,
+			OwnerID: event.User().ID,
+			Tracks:  tracks,
+		})
+		if errors.Is(err, playlist.ErrExists) {
+			b.respondEphemeral(event, "같은 이름의 플레이리스트가 이미 있습니다. 먼저 `/playlist delete`로 삭제하거나 다른 이름을 사용하세요.")
+			return
+		}
+		if err != nil {
+			b.respondEphemeral(event, "플레이리스트 저장 실패: "+err.Error())
+			return
+		}
+		b.respondEphemeral(event, fmt.Sprintf("%s 플레이리스트 **%s**에 %d곡을 저장했습니다.", scope, name, len(tracks)))
+
+	case "load":
+		p, err := b.Playlists.Get(scope, name)
+		if err != nil {
+			b.respondEphemeral(event, err.Error())
+			return
+		}
+		gp, ok := b.joinVoice(event)
+		if !ok {
+			return
+		}
+		b.handlePlaylist(gp, lavalink.Playlist{
+			Info:   lavalink.PlaylistInfo{Name: p.Name},
+			Tracks: p.Tracks,
+		}, event.User().ID, b.responder(event))
+
+	case "list":
+		if name != "" {
+			p, err := b.Playlists.Get(scope, name)
+			if err != nil {
+				b.respondEphemeral(event, err.Error())
+				return
+			}
+			_ = event.CreateMessage(discord.NewMessageCreateBuilder().
+				AddEmbeds(embed.PlaylistEmbed(scope, p)).
+				SetEphemeral(true).
+				Build())
+			return
+		}
+
+		lists, err := b.Playlists.List(scope)
+		if err != nil {
+			b.respondEphemeral(event, "플레이리스트 목록 읽기 실패: "+err.Error())
+			return
+		}
+		_ = event.CreateMessage(discord.NewMessageCreateBuilder().
+			AddEmbeds(embed.PlaylistListEmbed(scope, lists)).
+			SetEphemeral(true).
+			Build())
+
+	case "delete":
+		p, err := b.Playlists.Get(scope, name)
+		if err != nil {
+			b.respondEphemeral(event, err.Error())
+			return
+		}
+		if !canModify(event, scope, p) {
+			b.respondEphemeral(event, errNotOwner.Error())
+			return
+		}
+		if err := b.Playlists.Delete(scope, name); err != nil {
+			b.respondEphemeral(event, "플레이리스트 삭제 실패: "+err.Error())
+			return
+		}
+		b.respondEphemeral(event, fmt.Sprintf("%s 플레이리스트 **%s**을(를) 삭제했습니다.", scope, p.Name))
+
+	case "add":
+		b.handlePlaylistAdd(event, scope, name, data.String("query"))
+
+	case "remove":
+		pos := data.Int("position")
+		var removed lavalink.Track
+		p, err := b.updatePlaylist(event, scope, name, func(p *playlist.Playlist) error {
+			if pos < 1 || pos > len(p.Tracks) {
+				return errors.New("잘못된 위치입니다. `/playlist list`로 곡 목록을 확인하세요")
+			}
+			removed = p.Tracks[pos-1]
+			p.Tracks = slices.Delete(p.Tracks, pos-1, pos)
+			return nil
+		})
+		if err != nil {
+			b.respondEphemeral(event, err.Error())
+			return
+		}
+		b.respondEphemeral(event, fmt.Sprintf("**%s**에서 **%s**을(를) 삭제했습니다. (%d곡)", p.Name, removed.Info.Title, len(p.Tracks)))
+
+	case "rename":
+		newName := data.String("new_name")
+		oldName := name
+		p, err := b.updatePlaylist(event, scope, name, func(p *playlist.Playlist) error {
+			oldName = p.Name
+			p.Name = newName
+			return nil
+		})
+		if err != nil {
+			b.respondEphemeral(event, err.Error())
+			return
+		}
+		b.respondEphemeral(event, fmt.Sprintf("**%s**의 이름을 **%s**(으)로 바꿨습니다.", oldName, p.Name))
+	}
+}
+
+// handlePlaylistAdd는 검색어로 찾은 첫 곡(비어 있으면 현재 곡)을 플레이리스트 끝에 추가합니다.
+func (b *Bot) handlePlaylistAdd(event *events.ApplicationCommandInteractionCreate, scope playlist.Scope, name, query string) {
+	if _, err := b.Playlists.Get(scope, name); err != nil {
+		b.respondEphemeral(event, err.Error())
+		return
+	}
+
+	add := func(tracks ...lavalink.Track) {
+		if len(tracks) == 0 {
+			b.updateResponse(event, "검색 결과가 없습니다.")
+			return
+		}
+		p, err := b.updatePlaylist(event, scope, name, func(p *playlist.Playlist) error {
+			p.Tracks = append(p.Tracks, tracks...)
+			return nil
+		})
+		if err != nil {
+			b.updateResponse(event, err.Error())
+			return
+		}
+		if len(tracks) == 1 {
+			b.updateResponse(event, fmt.Sprintf("**%s**에 **%s**을(를) 추가했습니다. (%d곡)", p.Name, tracks[0].Info.Title, len(p.Tracks)))
+			return
+		}
+		b.updateResponse(event, fmt.Sprintf("**%s**에 %d곡을 추가했습니다. (%d곡)", p.Name, len(tracks), len(p.Tracks)))
+	}
+
+	_ = event.DeferCreateMessage(true)
+
+	if query == "" {
+		gp := b.GetOrCreatePlayer(*event.GuildID())
+		gp.Mu.Lock()
+		current := gp.CurrentTrack
+		gp.Mu.Unlock()
+		if current == nil {
+			b.updateResponse(event, "현재 재생 중인 곡이 없습니다. 추가할 곡의 검색어를 입력하세요.")
+			return
+		}
+		add(*current)
+		return
+	}
+
+	searchQuery := query
+	if !urlPattern.MatchString(query) {
+		searchQuery = b.searchSource(*event.GuildID(), "").Query(query)
+	}
+
+	node := b.bestNode(*event.GuildID(), "")
+	if node == nil {
+		b.updateResponse(event, "사용 가능한 Lavalink 노드가 없습니다.")
+		return
+	}
+
+	b.loadTracks(context.TODO(), node, searchQuery, disgolink.NewResultHandler(
+		func(track lavalink.Track) {
+			add(track)
+		},
+		func(pl lavalink.Playlist) {
+			add(pl.Tracks...)
+		},
+		func(tracks []lavalink.Track) {
+			add(tracks[:min(len(tracks), 1)]...)
+		},
+		func() {
+			b.updateResponse(event, "검색 결과가 없습니다.")
+		},
+		func(err error) {
+			slog.Error("트랙 로딩 실패", "error", err)
+			b.updateResponse(event, "트랙 로딩 실패: "+err.Error())
+		},
+	))
+}
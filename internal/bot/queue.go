@@ -0,0 +1,183 @@
+package bot
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgolink/v3/lavalink"
+	"github.com/uzih05/discord-music-bot/internal/embed"
+	"github.com/uzih05/discord-music-bot/internal/player"
+)
+
+const queueChanged = "대기열이 바뀌었습니다. 목록을 새로 고쳤으니 다시 선택해주세요."
+
+// queueMessage는 현재 재생 위치를 반영해 대기열 페이지를 만듭니다.
+func (b *Bot) queueMessage(gp *player.GuildPlayer, page int) (discord.Embed, []discord.ContainerComponent) {
+	var position lavalink.Duration
+	if p := b.Lavalink.ExistingPlayer(gp.GuildID); p != nil && p.Track() != nil {
+		position = p.Position()
+	}
+	return embed.QueueMessage(gp, page, position)
+}
+
+// queueTarget은 선택값이 가리키는 곡이 아직 같은 위치에 있는지 확인합니다.
+func queueTarget(gp *player.GuildPlayer, value string) (int, lavalink.Track, bool) {
+	pos, identifier, ok := embed.ParseQueueOptionValue(value)
+	if !ok {
+		return 0, lavalink.Track{}, false
+	}
+	track, ok := gp.At(pos)
+	if !ok || !strings.HasPrefix(track.Info.Identifier, identifier) {
+		return 0, lavalink.Track{}, false
+	}
+	return pos, track, true
+}
+
+func (b *Bot) updateQueueMessage(event *events.ComponentInteractionCreate, gp *player.GuildPlayer, page int, content string) {
+	e, components := b.queueMessage(gp, page)
+	_ = event.UpdateMessage(discord.NewMessageUpdateBuilder().
+		SetContent(content).
+		SetEmbeds(e).
+		SetContainerComponents(components...).
+		Build())
+}
+
+func (b *Bot) handleQueueComponent(event *events.ComponentInteractionCreate, customID string) {
+	action, pageStr, _ := strings.Cut(customID, ":")
+	page, err := strconv.Atoi(pageStr)
+	if err != nil {
+		return
+	}
+
+	guildID := *event.GuildID()
+	gp := b.GetOrCreatePlayer(guildID)
+
+	switch action {
+	case "queue_first":
+		b.updateQueueMessage(event, gp, 0, "")
+	case "queue_prev":
+		b.updateQueueMessage(event, gp, page-1, "")
+	case "queue_next":
+		b.updateQueueMessage(event, gp, page+1, "")
+	case "queue_last":
+		b.updateQueueMessage(event, gp, embed.QueueTotalPages(gp.QueueLen())-1, "")
+
+	case "queue_jump":
+		_ = event.Modal(discord.NewModalCreateBuilder().
+			SetCustomID("queue_jump_modal").
+			SetTitle("페이지 이동").
+			AddActionRow(discord.NewShortTextInput("page", fmt.Sprintf("페이지 (1-%d)", embed.QueueTotalPages(gp.QueueLen()))).
+				WithRequired(true).
+				WithMaxLength(4)).
+			Build())
+
+	case "queue_playnext", "queue_remove", "queue_move":
+		values := event.StringSelectMenuInteractionData().Values
+		if len(values) == 0 {
+			return
+		}
+		pos, track, ok := queueTarget(gp, values[0])
+		if !ok {
+			b.updateQueueMessage(event, gp, page, queueChanged)
+			return
+		}
+
+		permAction := "move"
+		if action == "queue_remove" {
+			permAction = "remove"
+		}
+		if !b.authorize(guildID, event.Member(), permAction, &track) {
+			_ = event.CreateMessage(discord.NewMessageCreateBuilder().
+				SetContent(permissionDenied).
+				SetEphemeral(true).
+				Build())
+			return
+		}
+
+		switch action {
+		case "queue_playnext":
+			if pos > 1 {
+				gp.Move(pos, 1)
+			}
+			b.updateQueueMessage(event, gp, page, fmt.Sprintf("**%s**을(를) 다음 곡으로 옮겼습니다.", track.Info.Title))
+
+		case "queue_remove":
+			gp.Remove(pos)
+			b.updateQueueMessage(event, gp, page, fmt.Sprintf("**%s**을(를) 대기열에서 삭제했습니다.", track.Info.Title))
+
+		case "queue_move":
+			_ = event.Modal(discord.NewModalCreateBuilder().
+				SetCustomID(fmt.Sprintf("queue_move_modal:%d:%s", page, values[0])).
+				SetTitle("곡 이동").
+				AddActionRow(discord.NewShortTextInput("position", fmt.Sprintf("%d번 곡을 옮길 위치 (1-%d)", pos, gp.QueueLen())).
+					WithRequired(true).
+					WithMaxLength(5)).
+				Build())
+		}
+	}
+}
+
+func (b *Bot) handleQueueModal(event *events.ModalSubmitInteractionCreate, customID string) {
+	guildID := *event.GuildID()
+	gp := b.GetOrCreatePlayer(guildID)
+
+	update := func(page int, content string) {
+		e, components := b.queueMessage(gp, page)
+		_ = event.UpdateMessage(discord.NewMessageUpdateBuilder().
+			SetContent(content).
+			SetEmbeds(e).
+			SetContainerComponents(components...).
+			Build())
+	}
+
+	if customID == "queue_jump_modal" {
+		page, err := strconv.Atoi(strings.TrimSpace(event.Data.Text("page")))
+		if err != nil {
+			update(0, "페이지 번호를 숫자로 입력해주세요.")
+			return
+		}
+		update(page-1, "")
+		return
+	}
+
+	// queue_move_modal:<page>:<선택값>
+	parts := strings.SplitN(customID, ":", 3)
+	if len(parts) != 3 {
+		return
+	}
+	page, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return
+	}
+
+	from, track, ok := queueTarget(gp, parts[2])
+	if !ok {
+		update(page, queueChanged)
+		return
+	}
+	if !b.authorize(guildID, event.Member(), "move", &track) {
+		_ = event.CreateMessage(discord.NewMessageCreateBuilder().
+			SetContent(permissionDenied).
+			SetEphemeral(true).
+			Build())
+		return
+	}
+
+	to, err := strconv.Atoi(strings.TrimSpace(event.Data.Text("position")))
+	if err != nil {
+		update(page, "위치를 숫자로 입력해주세요.")
+		return
+	}
+	if from == to {
+		update(page, "같은 위치입니다.")
+		return
+	}
+	if _, ok := gp.Move(from, to); !ok {
+		update(page, "잘못된 위치입니다.")
+		return
+	}
+	update((to-1)/embed.QueuePageSize, fmt.Sprintf("**%s**을(를) %d번에서 %d번으로 이동했습니다.", track.Info.Title, from, to))
+}
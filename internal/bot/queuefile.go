@@ -0,0 +1,243 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgolink/v3/disgolink"
+	"github.com/disgoorg/disgolink/v3/lavalink"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/uzih05/discord-music-bot/internal/queuefile"
+)
+
+// importTimeout은 대기열 파일 하나를 내려받고 곡을 모두 찾는 데 쓰는 최대 시간입니다.
+const importTimeout = 2 * time.Minute
+
+var downloadClient = &http.Client{Timeout: 10 * time.Second}
+
+func (b *Bot) handleQueue(event *events.ApplicationCommandInteractionCreate) {
+	data := event.SlashCommandInteractionData()
+	if data.SubCommandName == nil {
+		return
+	}
+
+	switch *data.SubCommandName {
+	case "view":
+		gp := b.GetOrCreatePlayer(*event.GuildID())
+		e, components := b.queueMessage(gp, 0)
+		_ = event.CreateMessage(discord.NewMessageCreateBuilder().
+			AddEmbeds(e).
+			AddContainerComponents(components...).
+			SetEphemeral(true).
+			Build())
+	case "export":
+		b.handleQueueExport(event)
+	case "import":
+		b.handleQueueImport(event)
+	}
+}
+
+// handleQueueExport는 현재 곡과 대기열 전체를 JSON과 M3U 파일로 첨부합니다.
+func (b *Bot) handleQueueExport(event *events.ApplicationCommandInteractionCreate) {
+	gp := b.GetOrCreatePlayer(*event.GuildID())
+	gp.Mu.Lock()
+	var tracks []lavalink.Track
+	if gp.CurrentTrack != nil {
+		tracks = append(tracks, *gp.CurrentTrack)
+	}
+	tracks = append(tracks, gp.Queue...)
+	gp.Mu.Unlock()
+
+	if len(tracks) == 0 {
+		b.respondEphemeral(event, "내보낼 곡이 없습니다.")
+		return
+	}
+
+	f := queuefile.New(tracks)
+	data, err := f.JSON()
+	if err != nil {
+		b.respondEphemeral(event, "대기열 내보내기 실패: "+err.Error())
+		return
+	}
+
+	_ = event.CreateMessage(discord.NewMessageCreateBuilder().
+		SetContentf("현재 곡과 대기열 %d곡을 내보냈습니다. `/queue import`로 다시 불러올 수 있습니다.", len(tracks)).
+		AddFile("queue.json", "대기열 (JSON)", bytes.NewReader(data)).
+		AddFile("queue.m3u", "대기열 (M3U)", bytes.NewReader(f.M3U())).
+		SetEphemeral(true).
+		Build())
+}
+
+// handleQueueImport는 첨부한 대기열 파일을 읽어 곡을 찾은 뒤 대기열에 추가하거나 대기열을 교체합니다.
+func (b *Bot) handleQueueImport(event *events.ApplicationCommandInteractionCreate) {
+	data := event.SlashCommandInteractionData()
+	guildID := *event.GuildID()
+	attachment := data.Attachment("file")
+	replace := data.String("mode") == "replace"
+
+	// 교체는 다른 사람이 신청한 곡까지 지우므로 삭제 권한이 필요
+	if replace && !b.authorize(guildID, event.Member(), "remove", nil) {
+		b.respondEphemeral(event, permissionDenied)
+		return
+	}
+	if attachment.Size > queuefile.MaxSize {
+		b.respondEphemeral(event, fmt.Sprintf("파일이 너무 큽니다. (최대 %dKB)", queuefile.MaxSize/1024))
+		return
+	}
+	voiceState := b.getVoiceChannelID(event)
+	if voiceState == nil {
+		b.respondEphemeral(event, "먼저 음성 채널에 접속해주세요!")
+		return
+	}
+
+	_ = event.DeferCreateMessage(true)
+
+	ctx, cancel := context.WithTimeout(context.Background(), importTimeout)
+	defer cancel()
+
+	raw, err := downloadAttachment(ctx, attachment.URL)
+	if err != nil {
+		slog.Error("대기열 파일 다운로드 실패", "error", err)
+		b.updateResponse(event, "파일을 받지 못했습니다: "+err.Error())
+		return
+	}
+	f, err := queuefile.Parse(raw)
+	if err != nil {
+		b.updateResponse(event, "대기열 파일을 읽지 못했습니다: "+err.Error())
+		return
+	}
+
+	tracks, missing, err := b.resolveQueueFile(ctx, guildID, f.Tracks)
+	if err != nil {
+		b.updateResponse(event, err.Error())
+		return
+	}
+	if len(tracks) == 0 {
+		b.updateResponse(event, "파일의 곡을 하나도 찾지 못했습니다.")
+		return
+	}
+
+	gp, err := b.connect(guildID, event.Channel().ID(), voiceState.ChannelID)
+	if err != nil {
+		b.updateResponse(event, "음성 채널 연결 실패: "+err.Error())
+		return
+	}
+	cleared := 0
+	if replace {
+		cleared = gp.ClearQueue()
+	}
+
+	respond := func(content string) {
+		if cleared > 0 {
+			content = fmt.Sprintf("기존 대기열 %d곡을 비웠습니다.\n", cleared) + content
+		}
+		if missing > 0 {
+			content += fmt.Sprintf("\n찾지 못한 %d곡은 건너뛰었습니다.", missing)
+		}
+		b.updateResponse(event, content)
+	}
+	b.handlePlaylist(gp, lavalink.Playlist{
+		Info:   lavalink.PlaylistInfo{Name: attachment.Filename},
+		Tracks: tracks,
+	}, event.User().ID, respond)
+}
+
+func downloadAttachment(ctx context.Context, url string) ([]byte, error) {
+	rq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	rs, err := downloadClient.Do(rq)
+	if err != nil {
+		return nil, err
+	}
+	defer rs.Body.Close()
+	if rs.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d", rs.StatusCode)
+	}
+	// 크기를 속인 첨부파일도 MaxSize를 넘으면 Parse에서 거절되도록 한 바이트 더 읽음
+	return io.ReadAll(io.LimitReader(rs.Body, queuefile.MaxSize+1))
+}
+
+// resolveQueueFile은 파일의 곡을 Lavalink 트랙으로 바꿉니다.
+// 인코딩된 트랙을 먼저 한 번에 디코딩하고, 인코딩이 없거나 디코딩에 실패하면 URI나 제목으로 다시 찾습니다.
+// 찾지 못한 곡은 건너뛰고 그 수를 반환합니다.
+func (b *Bot) resolveQueueFile(ctx context.Context, guildID snowflake.ID, entries []queuefile.Track) ([]lavalink.Track, int, error) {
+	node := b.bestNode(guildID, "")
+	if node == nil {
+		return nil, 0, errors.New("사용 가능한 Lavalink 노드가 없습니다")
+	}
+
+	resolved := make([]*lavalink.Track, len(entries))
+	var encoded []string
+	var indexes []int
+	for i, t := range entries {
+		if t.Encoded != "" {
+			encoded = append(encoded, t.Encoded)
+			indexes = append(indexes, i)
+		}
+	}
+	if len(encoded) > 0 {
+		// 다른 Lavalink 버전에서 만든 파일이면 디코딩이 통째로 실패할 수 있어 검색으로 넘어감
+		tracks, err := node.DecodeTracks(ctx, encoded)
+		if err != nil || len(tracks) != len(encoded) {
+			slog.Warn("대기열 파일 트랙 디코딩 실패, 검색으로 대체", "guild", guildID, "error", err)
+		} else {
+			for j, i := range indexes {
+				resolved[i] = &tracks[j]
+			}
+		}
+	}
+
+	source := b.searchSource(guildID, "")
+	for i, t := range entries {
+		if resolved[i] != nil {
+			continue
+		}
+		query := t.Query()
+		if query == "" {
+			continue
+		}
+		if !urlPattern.MatchString(query) {
+			query = source.Query(query)
+		}
+		b.loadTracks(ctx, node, query, disgolink.NewResultHandler(
+			func(track lavalink.Track) {
+				resolved[i] = &track
+			},
+			func(playlist lavalink.Playlist) {
+				if len(playlist.Tracks) > 0 {
+					resolved[i] = &playlist.Tracks[0]
+				}
+			},
+			func(tracks []lavalink.Track) {
+				if len(tracks) > 0 {
+					resolved[i] = &tracks[0]
+				}
+			},
+			func() {},
+			func(err error) {
+				slog.Debug("대기열 파일 곡 검색 실패", "query", query, "error", err)
+			},
+		))
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	tracks := make([]lavalink.Track, 0, len(entries))
+	for _, track := range resolved {
+		if track != nil {
+			tracks = append(tracks, *track)
+		}
+	}
+	return tracks, len(entries) - len(tracks), nil
+}
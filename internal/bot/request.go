@@ -0,0 +1,230 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/rest"
+	"github.com/disgoorg/disgolink/v3/disgolink"
+	"github.com/disgoorg/disgolink/v3/lavalink"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/uzih05/discord-music-bot/internal/embed"
+	"github.com/uzih05/discord-music-bot/internal/player"
+	"github.com/uzih05/discord-music-bot/internal/settings"
+)
+
+const (
+	// requestNoticeTTL이 지나면 신청 채널에 남긴 안내 메시지를 지웁니다.
+	requestNoticeTTL = 5 * time.Second
+	// panelDebounce 동안 생긴 변경은 패널 수정 한 번으로 모읍니다.
+	panelDebounce = time.Second
+)
+
+// onGuildMessageCreate는 음악 신청 채널에 올라온 메시지를 /play 검색어로 처리합니다.
+// 메시지 내용을 읽으려면 Message Content 인텐트가 필요합니다.
+func (b *Bot) onGuildMessageCreate(event *events.GuildMessageCreate) {
+	g := b.Settings.Get(event.GuildID)
+	if g.RequestChannelID == 0 || event.ChannelID != g.RequestChannelID {
+		return
+	}
+
+	// 패널을 고정할 때 생기는 시스템 메시지는 채널을 어지럽히므로 지움
+	if event.Message.Type == discord.MessageTypeChannelPinnedMessage {
+		_ = b.Client.Rest().DeleteMessage(event.ChannelID, event.MessageID)
+		return
+	}
+	if event.Message.Author.Bot || event.Message.WebhookID != nil {
+		return
+	}
+
+	// 트랙 검색이 다른 게이트웨이 이벤트를 막지 않도록 분리
+	go b.handleRequestMessage(event.GuildID, event.ChannelID, event.Message)
+}
+
+func (b *Bot) handleRequestMessage(guildID, channelID snowflake.ID, msg discord.Message) {
+	_ = b.Client.Rest().DeleteMessage(channelID, msg.ID)
+
+	query := strings.TrimSpace(msg.Content)
+	if query == "" {
+		return
+	}
+	userID := msg.Author.ID
+	notice := func(content string) {
+		b.requestNotice(channelID, userID, content)
+	}
+
+	voiceState, ok := b.Client.Caches().VoiceState(guildID, userID)
+	if !ok {
+		notice("먼저 음성 채널에 접속해주세요!")
+		return
+	}
+
+	gp, err := b.connect(guildID, channelID, voiceState.ChannelID)
+	if err != nil {
+		notice("음성 채널 연결 실패: " + err.Error())
+		return
+	}
+
+	node := b.bestNode(guildID, "")
+	if node == nil {
+		notice("사용 가능한 Lavalink 노드가 없습니다.")
+		return
+	}
+
+	searchQuery := query
+	if !urlPattern.MatchString(query) {
+		searchQuery = b.searchSource(guildID, "").Query(query)
+	}
+
+	// 신청 채널에서는 검색 결과 목록 없이 첫 번째 결과를 바로 추가
+	b.loadTracks(context.TODO(), node, searchQuery, disgolink.NewResultHandler(
+		func(track lavalink.Track) {
+			b.playOrQueue(gp, player.WithRequester(track, userID), notice)
+		},
+		func(playlist lavalink.Playlist) {
+			b.handlePlaylist(gp, playlist, userID, notice)
+		},
+		func(tracks []lavalink.Track) {
+			if len(tracks) == 0 {
+				notice("검색 결과가 없습니다.")
+				return
+			}
+			b.playOrQueue(gp, player.WithRequester(tracks[0], userID), notice)
+		},
+		func() {
+			notice("검색 결과가 없습니다.")
+		},
+		func(err error) {
+			slog.Error("트랙 로딩 실패", "error", err)
+			notice("트랙 로딩 실패: " + err.Error())
+		},
+	))
+}
+
+// requestNotice는 신청 채널에 사용자를 멘션한 안내를 남기고 잠시 뒤 지웁니다.
+func (b *Bot) requestNotice(channelID, userID snowflake.ID, content string) {
+	msg, err := b.Client.Rest().CreateMessage(channelID, discord.NewMessageCreateBuilder().
+		SetContentf("<@%s> %s", userID, content).
+		SetAllowedMentions(&discord.AllowedMentions{Users: []snowflake.ID{userID}}).
+		Build())
+	if err != nil {
+		slog.Error("신청 채널 안내 전송 실패", "error", err)
+		return
+	}
+	time.AfterFunc(requestNoticeTTL, func() {
+		_ = b.Client.Rest().DeleteMessage(channelID, msg.ID)
+	})
+}
+
+// playerChanged는 플레이어 상태가 바뀔 때마다 호출되어 상태를 저장하고 패널 수정을 예약하며 변경 이벤트를 보냅니다.
+func (b *Bot) playerChanged(gp *player.GuildPlayer) {
+	b.scheduleSave(gp.GuildID)
+	b.schedulePanelUpdate(gp.GuildID)
+	b.publishChanges(gp)
+}
+
+// schedulePanelUpdate는 panelDebounce 뒤에 신청 채널 패널을 수정하도록 예약합니다.
+func (b *Bot) schedulePanelUpdate(guildID snowflake.ID) {
+	// 종료 중에는 Discord 연결이 닫힌 뒤에 타이머가 돌 수 있으므로 예약하지 않음
+	if b.closing.Load() || b.Settings.Get(guildID).RequestChannelID == 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.panelTimers[guildID]; ok {
+		return
+	}
+	b.panelTimers[guildID] = time.AfterFunc(panelDebounce, func() {
+		b.mu.Lock()
+		delete(b.panelTimers, guildID)
+		b.mu.Unlock()
+		b.updatePanel(guildID)
+	})
+}
+
+// panelMessage는 길드의 현재 재생 상태로 패널 내용을 만듭니다.
+func (b *Bot) panelMessage(guildID snowflake.ID) ([]discord.Embed, []discord.ContainerComponent) {
+	gp := b.GetOrCreatePlayer(guildID)
+	gp.Mu.Lock()
+	track := gp.CurrentTrack
+	gp.Mu.Unlock()
+
+	var position lavalink.Duration
+	if p := b.Lavalink.ExistingPlayer(guildID); p != nil {
+		position = p.Position()
+	}
+	return embed.PanelMessage(gp, track, position)
+}
+
+// updatePanel은 신청 채널의 패널을 현재 상태로 수정합니다.
+// 패널이 없거나 지워졌으면 새로 보내고 고정합니다.
+func (b *Bot) updatePanel(guildID snowflake.ID) {
+	b.panelMu.Lock()
+	defer b.panelMu.Unlock()
+
+	g := b.Settings.Get(guildID)
+	if g.RequestChannelID == 0 {
+		return
+	}
+	embeds, components := b.panelMessage(guildID)
+
+	if g.RequestPanelID != 0 {
+		_, err := b.Client.Rest().UpdateMessage(g.RequestChannelID, g.RequestPanelID, discord.NewMessageUpdateBuilder().
+			SetEmbeds(embeds...).
+			SetContainerComponents(components...).
+			Build())
+		if err == nil {
+			return
+		}
+		if !isNotFound(err) {
+			slog.Debug("신청 채널 패널 수정 실패", "guild", guildID, "error", err)
+			return
+		}
+	}
+
+	msg, err := b.Client.Rest().CreateMessage(g.RequestChannelID, discord.NewMessageCreateBuilder().
+		AddEmbeds(embeds...).
+		AddContainerComponents(components...).
+		Build())
+	if err != nil {
+		slog.Error("신청 채널 패널 전송 실패", "guild", guildID, "error", err)
+		return
+	}
+	if err := b.Client.Rest().PinMessage(g.RequestChannelID, msg.ID); err != nil {
+		slog.Warn("신청 채널 패널 고정 실패", "guild", guildID, "error", err)
+	}
+
+	if _, err := b.Settings.Update(guildID, func(s *settings.Guild) {
+		// 그사이 신청 채널이 바뀌었다면 새 채널의 패널을 덮어쓰지 않음
+		if s.RequestChannelID == g.RequestChannelID {
+			s.RequestPanelID = msg.ID
+		}
+	}); err != nil {
+		slog.Error("신청 채널 패널 저장 실패", "guild", guildID, "error", err)
+	}
+}
+
+// syncRequestChannel은 신청 채널 설정이 바뀌었을 때 이전 패널을 지우고 새 채널에 패널을 만듭니다.
+func (b *Bot) syncRequestChannel(guildID snowflake.ID, before, after settings.Guild) {
+	if before.RequestChannelID == after.RequestChannelID {
+		return
+	}
+	if before.RequestPanelID != 0 {
+		_ = b.Client.Rest().DeleteMessage(before.RequestChannelID, before.RequestPanelID)
+	}
+	if after.RequestChannelID != 0 {
+		b.updatePanel(guildID)
+	}
+}
+
+func isNotFound(err error) bool {
+	var restErr rest.Error
+	return errors.As(err, &restErr) && restErr.Response != nil && restErr.Response.StatusCode == http.StatusNotFound
+}
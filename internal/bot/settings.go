@@ -0,0 +1,189 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgolink/v3/lavalink"
+	"github.com/uzih05/discord-music-bot/internal/embed"
+	"github.com/uzih05/discord-music-bot/internal/player"
+	"github.com/uzih05/discord-music-bot/internal/search"
+	"github.com/uzih05/discord-music-bot/internal/settings"
+)
+
+// tooLong은 트랙이 길드의 최대 곡 길이를 넘는지 확인합니다. 스트림은 길이 제한을 받지 않습니다.
+func tooLong(g settings.Guild, track lavalink.Track) bool {
+	limit := g.MaxTrackDuration()
+	return limit > 0 && !track.Info.IsStream && time.Duration(track.Info.Length)*time.Millisecond > limit
+}
+
+// limitTracks는 길드의 최대 곡 길이와 최대 대기열 길이에 맞춰 추가할 곡을 거릅니다.
+// playing이 false면 첫 곡은 바로 재생되므로 대기열 한도에 포함하지 않습니다.
+// 제외한 곡이 있으면 사용자에게 보여줄 안내 문구를 함께 반환합니다.
+func (b *Bot) limitTracks(gp *player.GuildPlayer, tracks []lavalink.Track, playing bool) ([]lavalink.Track, string) {
+	g := b.Settings.Get(gp.GuildID)
+
+	accepted := make([]lavalink.Track, 0, len(tracks))
+	long := 0
+	for _, track := range tracks {
+		if tooLong(g, track) {
+			long++
+			continue
+		}
+		accepted = append(accepted, track)
+	}
+
+	full := 0
+	if g.MaxQueueLength > 0 {
+		room := max(g.MaxQueueLength-gp.QueueLen(), 0)
+		if !playing {
+			room++
+		}
+		if len(accepted) > room {
+			full = len(accepted) - room
+			accepted = accepted[:room]
+		}
+	}
+
+	if len(tracks) == 1 && len(accepted) == 0 {
+		if long > 0 {
+			return accepted, fmt.Sprintf("최대 곡 길이(%d분)를 넘는 곡은 추가할 수 없습니다.", g.MaxTrackMinutes)
+		}
+		return accepted, fmt.Sprintf("대기열이 가득 찼습니다. (최대 %d곡)", g.MaxQueueLength)
+	}
+
+	var notes []string
+	if long > 0 {
+		notes = append(notes, fmt.Sprintf("%d분보다 긴 곡 %d곡", g.MaxTrackMinutes, long))
+	}
+	if full > 0 {
+		notes = append(notes, fmt.Sprintf("대기열 한도(%d곡)를 넘는 %d곡", g.MaxQueueLength, full))
+	}
+	if len(notes) == 0 {
+		return accepted, ""
+	}
+	return accepted, strings.Join(notes, ", ") + "은(는) 추가하지 않았습니다."
+}
+
+// settingKeys는 /settings reset에서 고를 수 있는 항목입니다.
+var settingKeys = map[string]func(g *settings.Guild){
+	"volume":           func(g *settings.Guild) { g.DefaultVolume = 0 },
+	"idle_timeout":     func(g *settings.Guild) { g.IdleTimeoutMinutes = 0 },
+	"announce_channel": func(g *settings.Guild) { g.AnnounceChannelID = 0 },
+	"max_queue":        func(g *settings.Guild) { g.MaxQueueLength = 0 },
+	"max_duration":     func(g *settings.Guild) { g.MaxTrackMinutes = 0 },
+	"search_source":    func(g *settings.Guild) { g.SearchSource = "" },
+	"persistent_np":    func(g *settings.Guild) { g.PersistentNowPlaying = false },
+	"request_channel": func(g *settings.Guild) {
+		g.RequestChannelID = 0
+		g.RequestPanelID = 0
+	},
+}
+
+func (b *Bot) handleSettings(event *events.ApplicationCommandInteractionCreate) {
+	data := event.SlashCommandInteractionData()
+	guildID := *event.GuildID()
+	if data.SubCommandName == nil {
+		return
+	}
+
+	respondSettings := func(content string, g settings.Guild) {
+		_ = event.CreateMessage(discord.NewMessageCreateBuilder().
+			SetContent(content).
+			AddEmbeds(embed.SettingsEmbed(g)).
+			SetEphemeral(true).
+			Build())
+	}
+
+	switch *data.SubCommandName {
+	case "view":
+		respondSettings("", b.Settings.Get(guildID))
+
+	case "set":
+		volume, hasVolume := data.OptInt("volume")
+		idle, hasIdle := data.OptInt("idle_timeout")
+		channelID, hasChannel := data.OptSnowflake("announce_channel")
+		maxQueue, hasMaxQueue := data.OptInt("max_queue")
+		maxDuration, hasMaxDuration := data.OptInt("max_duration")
+		sourceName, hasSource := data.OptString("search_source")
+		persistent, hasPersistent := data.OptBool("persistent_np")
+		requestID, hasRequest := data.OptSnowflake("request_channel")
+		if !hasVolume && !hasIdle && !hasChannel && !hasMaxQueue && !hasMaxDuration && !hasSource && !hasPersistent && !hasRequest {
+			b.respondEphemeral(event, "변경할 설정을 하나 이상 입력해주세요.")
+			return
+		}
+
+		// 인텐트 없이 채널을 지정하면 메시지를 읽지 못해 신청이 조용히 무시됨
+		if hasRequest && requestID != 0 && !b.requestChannels {
+			b.respondEphemeral(event, "음악 신청 채널이 꺼져 있습니다. 봇 관리자가 `REQUEST_CHANNEL=true`를 설정하고 Developer Portal에서 Message Content Intent를 켜야 합니다.")
+			return
+		}
+
+		before := b.Settings.Get(guildID)
+		source, ok := search.FindSource(sourceName)
+		if hasSource && !ok {
+			b.respondEphemeral(event, "알 수 없는 검색 소스입니다.")
+			return
+		}
+
+		g, err := b.Settings.Update(guildID, func(g *settings.Guild) {
+			if hasVolume {
+				g.DefaultVolume = volume
+			}
+			if hasIdle {
+				// 0분은 "퇴장하지 않음"
+				g.IdleTimeoutMinutes = idle
+				if idle == 0 {
+					g.IdleTimeoutMinutes = settings.IdleNever
+				}
+			}
+			if hasChannel {
+				g.AnnounceChannelID = channelID
+			}
+			if hasMaxQueue {
+				g.MaxQueueLength = maxQueue
+			}
+			if hasMaxDuration {
+				g.MaxTrackMinutes = maxDuration
+			}
+			if hasSource {
+				g.SearchSource = source.Name
+			}
+			if hasPersistent {
+				g.PersistentNowPlaying = persistent
+			}
+			if hasRequest && g.RequestChannelID != requestID {
+				g.RequestChannelID = requestID
+				g.RequestPanelID = 0
+			}
+		})
+		if err != nil {
+			b.respondEphemeral(event, "설정 저장 실패: "+err.Error())
+			return
+		}
+		respondSettings("설정을 변경했습니다.", g)
+		b.syncRequestChannel(guildID, before, g)
+
+	case "reset":
+		key := data.String("setting")
+		before := b.Settings.Get(guildID)
+		g, err := b.Settings.Update(guildID, func(g *settings.Guild) {
+			if reset, ok := settingKeys[key]; ok {
+				reset(g)
+				return
+			}
+			for _, reset := range settingKeys {
+				reset(g)
+			}
+		})
+		if err != nil {
+			b.respondEphemeral(event, "설정 저장 실패: "+err.Error())
+			return
+		}
+		respondSettings("설정을 기본값으로 되돌렸습니다.", g)
+		b.syncRequestChannel(guildID, before, g)
+	}
+}
@@ -0,0 +1,138 @@
+package bot
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/uzih05/discord-music-bot/internal/embed"
+	"github.com/uzih05/discord-music-bot/internal/player"
+)
+
+// shutdownTimeout은 종료 정리에 쓰는 최대 시간입니다. Docker의 기본 종료 대기(10초)보다 짧게 잡습니다.
+const shutdownTimeout = 8 * time.Second
+
+// shutdownPlayers는 모든 길드의 플레이어를 정리합니다.
+// 길드마다 병렬로 처리하며, ctx가 끝나면 남은 정리를 기다리지 않고 돌아갑니다.
+func (b *Bot) shutdownPlayers(ctx context.Context) {
+	b.mu.Lock()
+	players := make([]*player.GuildPlayer, 0, len(b.Players))
+	for _, gp := range b.Players {
+		players = append(players, gp)
+	}
+	for guildID, timer := range b.panelTimers {
+		timer.Stop()
+		delete(b.panelTimers, guildID)
+	}
+	// 예약된 저장은 shutdownPlayer가 바로 저장하므로 취소
+	for guildID, timer := range b.saveTimers {
+		timer.Stop()
+		delete(b.saveTimers, guildID)
+	}
+	b.mu.Unlock()
+
+	notice := os.Getenv("SHUTDOWN_NOTICE") == "true"
+
+	var wg sync.WaitGroup
+	for _, gp := range players {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			b.shutdownPlayer(ctx, gp, notice)
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		slog.Info("플레이어 정리 완료", "count", len(players))
+	case <-ctx.Done():
+		slog.Warn("종료 시간 안에 플레이어 정리를 끝내지 못했습니다", "error", ctx.Err())
+	}
+}
+
+// shutdownPlayer는 갱신 루프와 유휴 타이머를 멈추고, 스냅샷을 저장한 뒤
+// Now Playing/대기 중 메시지를 정리하고 음성 채널에서 나갑니다.
+// 스냅샷은 재생 위치와 음성 채널을 읽어야 하므로 Lavalink 플레이어를 없애기 전에 만듭니다.
+func (b *Bot) shutdownPlayer(ctx context.Context, gp *player.GuildPlayer, notice bool) {
+	gp.StopUpdateLoop()
+	gp.CancelIdleTimer()
+
+	s := b.snapshotPlayer(gp)
+	restorable := !s.Empty() && s.VoiceChannelID != 0
+
+	gp.Mu.Lock()
+	npMsgID, npChID := gp.NowPlayingMessageID, gp.NowPlayingChannelID
+	idleMsgID, idleChID := gp.IdleMessageID, gp.IdleChannelID
+	gp.NowPlayingMessageID, gp.NowPlayingChannelID = 0, 0
+	gp.IdleMessageID, gp.IdleChannelID = 0, 0
+	gp.Mu.Unlock()
+
+	// 복원할 재생이 있을 때만 안내를 남기고, 복원하면서 지움
+	if notice && restorable {
+		s.NoticeChannelID, s.NoticeMessageID = b.postRestartNotice(ctx, gp, npChID, npMsgID)
+		if s.NoticeMessageID == npMsgID {
+			npMsgID = 0
+		}
+	}
+	if npMsgID != 0 {
+		_ = b.Client.Rest().DeleteMessage(npChID, npMsgID, rest.WithCtx(ctx))
+	}
+	if idleMsgID != 0 {
+		_ = b.Client.Rest().DeleteMessage(idleChID, idleMsgID, rest.WithCtx(ctx))
+	}
+
+	// 이미 돌고 있던 예약 저장과 겹치지 않도록 saveMu 안에서 기록
+	b.saveMu.Lock()
+	b.storeSnapshot(s)
+	b.saveMu.Unlock()
+
+	if p := b.Lavalink.ExistingPlayer(gp.GuildID); p != nil {
+		if err := p.Destroy(ctx); err != nil {
+			slog.Debug("Lavalink 플레이어 제거 실패", "guild", gp.GuildID, "error", err)
+		}
+	}
+	if s.VoiceChannelID != 0 {
+		if err := b.Client.UpdateVoiceState(ctx, gp.GuildID, nil, false, false); err != nil {
+			slog.Debug("음성 채널 퇴장 실패", "guild", gp.GuildID, "error", err)
+		}
+	}
+}
+
+// postRestartNotice는 Now Playing 메시지가 있으면 재시작 안내로 바꾸고, 없으면 안내 채널에 새로 보냅니다.
+// 안내를 남기지 못하면 0을 반환합니다.
+func (b *Bot) postRestartNotice(ctx context.Context, gp *player.GuildPlayer, channelID, messageID snowflake.ID) (snowflake.ID, snowflake.ID) {
+	if messageID != 0 {
+		_, err := b.Client.Rest().UpdateMessage(channelID, messageID, discord.NewMessageUpdateBuilder().
+			SetEmbeds(embed.RestartEmbed()).
+			ClearContainerComponents().
+			Build(), rest.WithCtx(ctx))
+		if err == nil {
+			return channelID, messageID
+		}
+		slog.Debug("재시작 안내로 수정 실패", "guild", gp.GuildID, "error", err)
+	}
+
+	channelID = b.announceChannel(gp)
+	// 신청 채널에는 패널 외의 메시지를 남기지 않음
+	if channelID == 0 || channelID == b.Settings.Get(gp.GuildID).RequestChannelID {
+		return 0, 0
+	}
+	msg, err := b.Client.Rest().CreateMessage(channelID, discord.NewMessageCreateBuilder().
+		AddEmbeds(embed.RestartEmbed()).
+		Build(), rest.WithCtx(ctx))
+	if err != nil {
+		slog.Warn("재시작 안내 전송 실패", "guild", gp.GuildID, "error", err)
+		return 0, 0
+	}
+	return channelID, msg.ID
+}
@@ -0,0 +1,65 @@
+package bot
+
+import (
+	"hash/fnv"
+	"slices"
+
+	"github.com/disgoorg/disgolink/v3/lavalink"
+	"github.com/uzih05/discord-music-bot/internal/api"
+	"github.com/uzih05/discord-music-bot/internal/player"
+)
+
+// streamState는 변경 이벤트를 만들기 위해 마지막으로 알린 플레이어 상태입니다.
+type streamState struct {
+	queue     uint64
+	volume    int
+	repeat    player.RepeatMode
+	queueMode player.QueueMode
+}
+
+// streamStateOf는 gp의 현재 상태를 읽습니다. gp.Mu를 잡은 상태에서 호출해야 합니다.
+func streamStateOf(gp *player.GuildPlayer) streamState {
+	return streamState{
+		queue:     queueSignature(gp.Queue),
+		volume:    gp.Volume,
+		repeat:    gp.Repeat,
+		queueMode: gp.QueueMode,
+	}
+}
+
+// queueSignature는 대기열 곡과 순서가 같으면 같은 값을 돌려줍니다.
+func queueSignature(queue []lavalink.Track) uint64 {
+	h := fnv.New64a()
+	for _, track := range queue {
+		_, _ = h.Write([]byte(track.Encoded))
+		_, _ = h.Write([]byte{0})
+	}
+	return h.Sum64()
+}
+
+// publishChanges는 마지막으로 알린 상태와 비교해 바뀐 항목만 이벤트로 보냅니다.
+// 플레이어를 바꾸는 경로(명령어, 버튼, API, 신청 채널)가 모두 OnChange를 거치므로 여기서 한 번에 처리합니다.
+func (b *Bot) publishChanges(gp *player.GuildPlayer) {
+	gp.Mu.Lock()
+	state := streamStateOf(gp)
+	queue := slices.Clone(gp.Queue)
+	gp.Mu.Unlock()
+
+	b.streamMu.Lock()
+	last := b.streamStates[gp.GuildID]
+	b.streamStates[gp.GuildID] = state
+	b.streamMu.Unlock()
+
+	if state.queue != last.queue {
+		b.Events.Publish(gp.GuildID, api.EventQueueUpdate, api.QueueData{Queue: api.NewTracks(queue)})
+	}
+	if state.volume != last.volume {
+		b.Events.Publish(gp.GuildID, api.EventVolumeUpdate, api.VolumeData{Volume: state.volume})
+	}
+	if state.repeat != last.repeat {
+		b.Events.Publish(gp.GuildID, api.EventRepeatUpdate, api.RepeatData{Repeat: api.RepeatName(state.repeat)})
+	}
+	if state.queueMode != last.queueMode {
+		b.Events.Publish(gp.GuildID, api.EventQueueModeUpdate, api.QueueModeData{QueueMode: api.QueueModeName(state.queueMode)})
+	}
+}
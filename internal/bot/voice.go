@@ -0,0 +1,55 @@
+package bot
+
+import (
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// rememberMember는 음성 채널 인원 계산에서 봇 계정을 빼기 위해 봇 여부를 기록합니다.
+// 멤버 캐시를 쓰지 않으므로 음성 상태 이벤트와 GuildReady에 포함된 멤버 정보만 사용합니다.
+func (b *Bot) rememberMember(member discord.Member) {
+	if !member.User.Bot {
+		return
+	}
+	b.mu.Lock()
+	b.bots[member.User.ID] = struct{}{}
+	b.mu.Unlock()
+}
+
+// botChannelID는 봇이 접속한 음성 채널을 반환합니다.
+func (b *Bot) botChannelID(guildID snowflake.ID) *snowflake.ID {
+	vs, ok := b.Client.Caches().VoiceState(guildID, b.Client.ApplicationID())
+	if !ok {
+		return nil
+	}
+	return vs.ChannelID
+}
+
+// listeners는 봇과 같은 음성 채널에 있는 봇이 아닌 사용자 목록입니다.
+func (b *Bot) listeners(guildID snowflake.ID) []snowflake.ID {
+	channelID := b.botChannelID(guildID)
+	if channelID == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var users []snowflake.ID
+	b.Client.Caches().VoiceStatesForEach(guildID, func(vs discord.VoiceState) {
+		if vs.ChannelID == nil || *vs.ChannelID != *channelID || vs.UserID == b.Client.ApplicationID() {
+			return
+		}
+		if _, isBot := b.bots[vs.UserID]; isBot {
+			return
+		}
+		users = append(users, vs.UserID)
+	})
+	return users
+}
+
+// aloneWithBot은 userID가 봇과 단둘이 음성 채널에 있는지 확인합니다.
+func (b *Bot) aloneWithBot(guildID snowflake.ID, userID snowflake.ID) bool {
+	users := b.listeners(guildID)
+	return len(users) == 1 && users[0] == userID
+}
@@ -0,0 +1,56 @@
+package bot
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/uzih05/discord-music-bot/internal/permission"
+	"github.com/uzih05/discord-music-bot/internal/player"
+)
+
+var errNotListening = errors.New("봇과 같은 음성 채널에 있어야 투표할 수 있습니다")
+
+// skipVote는 투표 스킵 모드에서 스킵 요청을 처리합니다.
+// DJ, 곡 신청자, 봇과 단둘이 있는 사용자는 바로 스킵할 수 있고(skip=true),
+// 그 외에는 투표를 기록한 뒤 필요한 표가 모였는지 알려줍니다.
+func (b *Bot) skipVote(guildID snowflake.ID, member *discord.ResolvedMember, gp *player.GuildPlayer) (skip bool, votes, needed int, err error) {
+	g := b.Settings.Get(guildID)
+	if !g.VoteSkip || member == nil {
+		return true, 0, 0, nil
+	}
+
+	gp.Mu.Lock()
+	current := gp.CurrentTrack
+	gp.Mu.Unlock()
+
+	if permission.IsDJ(g, toPermissionMember(guildID, member)) ||
+		(current != nil && player.Requester(*current) == member.User.ID) {
+		return true, 0, 0, nil
+	}
+
+	users := b.listeners(guildID)
+	if !slices.Contains(users, member.User.ID) {
+		return false, 0, 0, errNotListening
+	}
+	if len(users) == 1 {
+		return true, 0, 0, nil
+	}
+
+	needed = g.SkipVotesNeeded(len(users))
+	votes = gp.AddSkipVote(member.User.ID, users, needed)
+	return votes >= needed, votes, needed, nil
+}
+
+// pruneSkipVotes는 음성 채널을 떠난 사용자의 스킵 표를 지우고 필요한 표 수를 청취자 수에 맞춥니다.
+func (b *Bot) pruneSkipVotes(guildID snowflake.ID) {
+	b.mu.Lock()
+	gp, ok := b.Players[guildID]
+	b.mu.Unlock()
+	if !ok {
+		return
+	}
+	users := b.listeners(guildID)
+	gp.PruneSkipVotes(users, b.Settings.Get(guildID).SkipVotesNeeded(len(users)))
+}
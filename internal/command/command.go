@@ -1,6 +1,10 @@
 package command
 
-import "github.com/disgoorg/disgo/discord"
+import (
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/json"
+	"github.com/uzih05/discord-music-bot/internal/search"
+)
 
 var (
 	dmPerm = false
@@ -9,17 +13,33 @@ var (
 
 	// HelpEntries는 /help에서 표시할 명령어 목록 (등록 순서대로)
 	HelpEntries = []HelpEntry{
-		{Command: "/play <검색어>", Korean: "/재생", Description: "노래를 재생합니다 (검색어 또는 URL)"},
+		{Command: "/play <검색어> [소스]", Korean: "/재생", Description: "노래를 재생합니다 (검색어 또는 URL, YouTube/SoundCloud 등)"},
 		{Command: "/pause", Korean: "/일시정지", Description: "일시정지 또는 재개합니다"},
 		{Command: "/skip", Korean: "/스킵", Description: "현재 곡을 스킵합니다"},
+		{Command: "/previous", Korean: "/이전", Description: "이전 곡을 다시 재생합니다"},
+		{Command: "/seek <시간>", Korean: "/탐색", Description: "재생 위치를 이동합니다 (1:23, 1:02:03, 90s, +30, -15)"},
+		{Command: "/forward [초]", Korean: "/앞으로", Description: "앞으로 건너뜁니다 (기본 10초)"},
+		{Command: "/rewind [초]", Korean: "/뒤로", Description: "뒤로 되감습니다 (기본 10초)"},
 		{Command: "/stop", Korean: "/정지", Description: "재생을 중지하고 채널에서 나갑니다"},
-		{Command: "/queue", Korean: "/대기열", Description: "현재 대기열을 표시합니다"},
+		{Command: "/queue <view|export|import>", Korean: "/대기열", Description: "대기열을 페이지별로 표시하고 곡을 조작하거나, 파일로 내보내고 가져옵니다"},
 		{Command: "/move <시작> <끝>", Korean: "/이동", Description: "대기열에서 곡 순서를 이동합니다"},
 		{Command: "/remove <위치>", Korean: "/삭제", Description: "대기열에서 곡을 삭제합니다"},
+		{Command: "/remove-mine", Korean: "/내곡삭제", Description: "내가 신청한 곡을 대기열에서 모두 삭제합니다"},
 		{Command: "/volume <0-100>", Korean: "/볼륨", Description: "볼륨을 조절합니다"},
 		{Command: "/repeat <모드>", Korean: "/반복", Description: "반복 모드 (끄기 / 한 곡 / 전체)"},
+		{Command: "/queuemode <모드>", Korean: "/대기열모드", Description: "대기열 모드 (순서대로 / 신청자별 공평 분배)"},
+		{Command: "/autoplay <켜기/끄기>", Korean: "/자동재생", Description: "대기열이 비면 비슷한 곡을 자동으로 재생합니다"},
+		{Command: "/filter <필터>", Korean: "/필터", Description: "오디오 필터를 켜거나 끕니다 (베이스 부스트, 나이트코어, 8D 등)"},
+		{Command: "/eq <set|reset|show>", Korean: "/이퀄라이저", Description: "15밴드 이퀄라이저를 조절합니다"},
 		{Command: "/shuffle", Korean: "/셔플", Description: "대기열을 셔플합니다"},
 		{Command: "/nowplaying", Korean: "/현재곡", Description: "현재 재생 중인 곡 정보"},
+		{Command: "/history", Korean: "/기록", Description: "최근 재생 기록을 표시합니다"},
+		{Command: "/lyrics [검색어]", Korean: "/가사", Description: "현재 곡 또는 검색한 곡의 가사를 표시합니다"},
+		{Command: "/playlist <save|load|list|delete|add|remove|rename>", Korean: "/플레이리스트", Description: "개인/서버 플레이리스트를 저장하고 불러옵니다"},
+		{Command: "/source <소스>", Korean: "/검색소스", Description: "서버 기본 검색 소스를 설정합니다 (서버 관리 권한 필요)"},
+		{Command: "/settings <view|set|reset>", Korean: "/설정", Description: "기본 볼륨, 자동 퇴장, 안내 채널, 대기열 제한 등 서버 설정 (서버 관리 권한 필요)"},
+		{Command: "/dj <role|allow|reset|voteskip|show>", Korean: "/디제이", Description: "DJ 역할과 명령어별 권한을 설정합니다 (서버 관리 권한 필요)"},
+		{Command: "/api <token|revoke>", Korean: "/api", Description: "웹 컨트롤러용 HTTP API 토큰을 발급하거나 폐기합니다 (서버 관리 권한 필요)"},
 		{Command: "/help", Korean: "/도움말", Description: "이 도움말을 표시합니다"},
 	}
 
@@ -34,9 +54,17 @@ var (
 				discord.ApplicationCommandOptionString{
 					Name:                     "query",
 					NameLocalizations:        map[discord.Locale]string{ko: "검색어"},
-					Description:              "검색어 또는 YouTube URL",
-					DescriptionLocalizations: map[discord.Locale]string{ko: "검색어 또는 YouTube URL"},
+					Description:              "검색어 또는 URL (입력하면 추천 곡 표시)",
+					DescriptionLocalizations: map[discord.Locale]string{ko: "검색어 또는 URL (입력하면 추천 곡 표시)"},
 					Required:                 true,
+					Autocomplete:             true,
+				},
+				discord.ApplicationCommandOptionString{
+					Name:                     "source",
+					NameLocalizations:        map[discord.Locale]string{ko: "소스"},
+					Description:              "검색할 플랫폼 (기본: 서버 설정)",
+					DescriptionLocalizations: map[discord.Locale]string{ko: "검색할 플랫폼 (기본: 서버 설정)"},
+					Choices:                  sourceChoices(),
 				},
 			},
 		},
@@ -54,6 +82,61 @@ var (
 			DescriptionLocalizations: map[discord.Locale]string{ko: "현재 곡을 스킵합니다"},
 			DMPermission:             &dmPerm,
 		},
+		discord.SlashCommandCreate{
+			Name:                     "previous",
+			NameLocalizations:        map[discord.Locale]string{ko: "이전"},
+			Description:              "이전 곡을 다시 재생합니다",
+			DescriptionLocalizations: map[discord.Locale]string{ko: "이전 곡을 다시 재생합니다"},
+			DMPermission:             &dmPerm,
+		},
+		discord.SlashCommandCreate{
+			Name:                     "seek",
+			NameLocalizations:        map[discord.Locale]string{ko: "탐색"},
+			Description:              "재생 위치를 이동합니다",
+			DescriptionLocalizations: map[discord.Locale]string{ko: "재생 위치를 이동합니다"},
+			DMPermission:             &dmPerm,
+			Options: []discord.ApplicationCommandOption{
+				discord.ApplicationCommandOptionString{
+					Name:                     "time",
+					NameLocalizations:        map[discord.Locale]string{ko: "시간"},
+					Description:              "이동할 위치 (1:23, 1:02:03, 90s, +30, -15)",
+					DescriptionLocalizations: map[discord.Locale]string{ko: "이동할 위치 (1:23, 1:02:03, 90s, +30, -15)"},
+					Required:                 true,
+				},
+			},
+		},
+		discord.SlashCommandCreate{
+			Name:                     "forward",
+			NameLocalizations:        map[discord.Locale]string{ko: "앞으로"},
+			Description:              "앞으로 건너뜁니다",
+			DescriptionLocalizations: map[discord.Locale]string{ko: "앞으로 건너뜁니다"},
+			DMPermission:             &dmPerm,
+			Options: []discord.ApplicationCommandOption{
+				discord.ApplicationCommandOptionInt{
+					Name:                     "seconds",
+					NameLocalizations:        map[discord.Locale]string{ko: "초"},
+					Description:              "건너뛸 시간 (초, 기본 10)",
+					DescriptionLocalizations: map[discord.Locale]string{ko: "건너뛸 시간 (초, 기본 10)"},
+					MinValue:                 intPtr(1),
+				},
+			},
+		},
+		discord.SlashCommandCreate{
+			Name:                     "rewind",
+			NameLocalizations:        map[discord.Locale]string{ko: "뒤로"},
+			Description:              "뒤로 되감습니다",
+			DescriptionLocalizations: map[discord.Locale]string{ko: "뒤로 되감습니다"},
+			DMPermission:             &dmPerm,
+			Options: []discord.ApplicationCommandOption{
+				discord.ApplicationCommandOptionInt{
+					Name:                     "seconds",
+					NameLocalizations:        map[discord.Locale]string{ko: "초"},
+					Description:              "되감을 시간 (초, 기본 10)",
+					DescriptionLocalizations: map[discord.Locale]string{ko: "되감을 시간 (초, 기본 10)"},
+					MinValue:                 intPtr(1),
+				},
+			},
+		},
 		discord.SlashCommandCreate{
 			Name:                     "stop",
 			NameLocalizations:        map[discord.Locale]string{ko: "정지"},
@@ -64,9 +147,48 @@ var (
 		discord.SlashCommandCreate{
 			Name:                     "queue",
 			NameLocalizations:        map[discord.Locale]string{ko: "대기열"},
-			Description:              "현재 대기열을 표시합니다",
-			DescriptionLocalizations: map[discord.Locale]string{ko: "현재 대기열을 표시합니다"},
+			Description:              "대기열을 표시하거나 파일로 내보내고 가져옵니다",
+			DescriptionLocalizations: map[discord.Locale]string{ko: "대기열을 표시하거나 파일로 내보내고 가져옵니다"},
 			DMPermission:             &dmPerm,
+			Options: []discord.ApplicationCommandOption{
+				discord.ApplicationCommandOptionSubCommand{
+					Name:                     "view",
+					NameLocalizations:        map[discord.Locale]string{ko: "보기"},
+					Description:              "현재 대기열을 표시합니다",
+					DescriptionLocalizations: map[discord.Locale]string{ko: "현재 대기열을 표시합니다"},
+				},
+				discord.ApplicationCommandOptionSubCommand{
+					Name:                     "export",
+					NameLocalizations:        map[discord.Locale]string{ko: "내보내기"},
+					Description:              "현재 곡과 대기열을 JSON/M3U 파일로 내보냅니다",
+					DescriptionLocalizations: map[discord.Locale]string{ko: "현재 곡과 대기열을 JSON/M3U 파일로 내보냅니다"},
+				},
+				discord.ApplicationCommandOptionSubCommand{
+					Name:                     "import",
+					NameLocalizations:        map[discord.Locale]string{ko: "가져오기"},
+					Description:              "내보낸 대기열 파일(JSON/M3U)을 대기열에 추가합니다",
+					DescriptionLocalizations: map[discord.Locale]string{ko: "내보낸 대기열 파일(JSON/M3U)을 대기열에 추가합니다"},
+					Options: []discord.ApplicationCommandOption{
+						discord.ApplicationCommandOptionAttachment{
+							Name:                     "file",
+							NameLocalizations:        map[discord.Locale]string{ko: "파일"},
+							Description:              "/queue export로 만든 파일 또는 M3U 재생목록",
+							DescriptionLocalizations: map[discord.Locale]string{ko: "/queue export로 만든 파일 또는 M3U 재생목록"},
+							Required:                 true,
+						},
+						discord.ApplicationCommandOptionString{
+							Name:                     "mode",
+							NameLocalizations:        map[discord.Locale]string{ko: "방식"},
+							Description:              "대기열 뒤에 추가하거나 대기열을 비우고 교체 (기본 추가)",
+							DescriptionLocalizations: map[discord.Locale]string{ko: "대기열 뒤에 추가하거나 대기열을 비우고 교체 (기본 추가)"},
+							Choices: []discord.ApplicationCommandOptionChoiceString{
+								{Name: "추가", Value: "append"},
+								{Name: "교체", Value: "replace"},
+							},
+						},
+					},
+				},
+			},
 		},
 		discord.SlashCommandCreate{
 			Name:                     "move",
@@ -110,6 +232,13 @@ var (
 				},
 			},
 		},
+		discord.SlashCommandCreate{
+			Name:                     "remove-mine",
+			NameLocalizations:        map[discord.Locale]string{ko: "내곡삭제"},
+			Description:              "내가 신청한 곡을 대기열에서 모두 삭제합니다",
+			DescriptionLocalizations: map[discord.Locale]string{ko: "내가 신청한 곡을 대기열에서 모두 삭제합니다"},
+			DMPermission:             &dmPerm,
+		},
 		discord.SlashCommandCreate{
 			Name:                     "volume",
 			NameLocalizations:        map[discord.Locale]string{ko: "볼륨"},
@@ -149,6 +278,115 @@ var (
 				},
 			},
 		},
+		discord.SlashCommandCreate{
+			Name:                     "queuemode",
+			NameLocalizations:        map[discord.Locale]string{ko: "대기열모드"},
+			Description:              "대기열 재생 순서 모드를 설정합니다",
+			DescriptionLocalizations: map[discord.Locale]string{ko: "대기열 재생 순서 모드를 설정합니다"},
+			DMPermission:             &dmPerm,
+			Options: []discord.ApplicationCommandOption{
+				discord.ApplicationCommandOptionString{
+					Name:                     "mode",
+					NameLocalizations:        map[discord.Locale]string{ko: "모드"},
+					Description:              "대기열 모드",
+					DescriptionLocalizations: map[discord.Locale]string{ko: "대기열 모드"},
+					Required:                 true,
+					Choices: []discord.ApplicationCommandOptionChoiceString{
+						{Name: "순서대로", Value: "fifo"},
+						{Name: "공평 분배 (신청자별 번갈아)", Value: "fair"},
+					},
+				},
+			},
+		},
+		discord.SlashCommandCreate{
+			Name:                     "autoplay",
+			NameLocalizations:        map[discord.Locale]string{ko: "자동재생"},
+			Description:              "대기열이 비면 최근 재생 기록과 비슷한 곡을 자동으로 재생합니다",
+			DescriptionLocalizations: map[discord.Locale]string{ko: "대기열이 비면 최근 재생 기록과 비슷한 곡을 자동으로 재생합니다"},
+			DMPermission:             &dmPerm,
+			Options: []discord.ApplicationCommandOption{
+				discord.ApplicationCommandOptionBool{
+					Name:                     "enabled",
+					NameLocalizations:        map[discord.Locale]string{ko: "사용"},
+					Description:              "자동 재생 사용 여부",
+					DescriptionLocalizations: map[discord.Locale]string{ko: "자동 재생 사용 여부"},
+					Required:                 true,
+				},
+			},
+		},
+		discord.SlashCommandCreate{
+			Name:                     "filter",
+			NameLocalizations:        map[discord.Locale]string{ko: "필터"},
+			Description:              "오디오 필터를 켜거나 끕니다",
+			DescriptionLocalizations: map[discord.Locale]string{ko: "오디오 필터를 켜거나 끕니다"},
+			DMPermission:             &dmPerm,
+			Options: []discord.ApplicationCommandOption{
+				discord.ApplicationCommandOptionString{
+					Name:                     "preset",
+					NameLocalizations:        map[discord.Locale]string{ko: "필터"},
+					Description:              "켜거나 끌 필터 (끄기: 모든 필터 해제)",
+					DescriptionLocalizations: map[discord.Locale]string{ko: "켜거나 끌 필터 (끄기: 모든 필터 해제)"},
+					Required:                 true,
+					Choices: []discord.ApplicationCommandOptionChoiceString{
+						{Name: "베이스 부스트", Value: "bassboost"},
+						{Name: "나이트코어", Value: "nightcore"},
+						{Name: "베이퍼웨이브", Value: "vaporwave"},
+						{Name: "8D", Value: "8d"},
+						{Name: "노래방 (보컬 제거)", Value: "karaoke"},
+						{Name: "트레몰로", Value: "tremolo"},
+						{Name: "로우패스 (부드럽게)", Value: "lowpass"},
+						{Name: "모두 끄기", Value: "off"},
+					},
+				},
+			},
+		},
+		discord.SlashCommandCreate{
+			Name:                     "eq",
+			NameLocalizations:        map[discord.Locale]string{ko: "이퀄라이저"},
+			Description:              "15밴드 이퀄라이저를 조절합니다",
+			DescriptionLocalizations: map[discord.Locale]string{ko: "15밴드 이퀄라이저를 조절합니다"},
+			DMPermission:             &dmPerm,
+			Options: []discord.ApplicationCommandOption{
+				discord.ApplicationCommandOptionSubCommand{
+					Name:                     "set",
+					NameLocalizations:        map[discord.Locale]string{ko: "설정"},
+					Description:              "밴드 하나의 게인을 설정합니다",
+					DescriptionLocalizations: map[discord.Locale]string{ko: "밴드 하나의 게인을 설정합니다"},
+					Options: []discord.ApplicationCommandOption{
+						discord.ApplicationCommandOptionInt{
+							Name:                     "band",
+							NameLocalizations:        map[discord.Locale]string{ko: "밴드"},
+							Description:              "밴드 번호 (0: 25Hz ~ 14: 16kHz)",
+							DescriptionLocalizations: map[discord.Locale]string{ko: "밴드 번호 (0: 25Hz ~ 14: 16kHz)"},
+							Required:                 true,
+							MinValue:                 intPtr(0),
+							MaxValue:                 intPtr(14),
+						},
+						discord.ApplicationCommandOptionFloat{
+							Name:                     "gain",
+							NameLocalizations:        map[discord.Locale]string{ko: "게인"},
+							Description:              "게인 (-0.25 ~ 1.0, 0은 원래대로)",
+							DescriptionLocalizations: map[discord.Locale]string{ko: "게인 (-0.25 ~ 1.0, 0은 원래대로)"},
+							Required:                 true,
+							MinValue:                 floatPtr(-0.25),
+							MaxValue:                 floatPtr(1.0),
+						},
+					},
+				},
+				discord.ApplicationCommandOptionSubCommand{
+					Name:                     "reset",
+					NameLocalizations:        map[discord.Locale]string{ko: "초기화"},
+					Description:              "이퀄라이저를 초기화합니다",
+					DescriptionLocalizations: map[discord.Locale]string{ko: "이퀄라이저를 초기화합니다"},
+				},
+				discord.ApplicationCommandOptionSubCommand{
+					Name:                     "show",
+					NameLocalizations:        map[discord.Locale]string{ko: "보기"},
+					Description:              "현재 이퀄라이저와 필터 설정을 표시합니다",
+					DescriptionLocalizations: map[discord.Locale]string{ko: "현재 이퀄라이저와 필터 설정을 표시합니다"},
+				},
+			},
+		},
 		discord.SlashCommandCreate{
 			Name:                     "shuffle",
 			NameLocalizations:        map[discord.Locale]string{ko: "셔플"},
@@ -163,6 +401,362 @@ var (
 			DescriptionLocalizations: map[discord.Locale]string{ko: "현재 재생 중인 곡 정보를 표시합니다"},
 			DMPermission:             &dmPerm,
 		},
+		discord.SlashCommandCreate{
+			Name:                     "history",
+			NameLocalizations:        map[discord.Locale]string{ko: "기록"},
+			Description:              "최근 재생 기록을 표시합니다",
+			DescriptionLocalizations: map[discord.Locale]string{ko: "최근 재생 기록을 표시합니다"},
+			DMPermission:             &dmPerm,
+		},
+		discord.SlashCommandCreate{
+			Name:                     "lyrics",
+			NameLocalizations:        map[discord.Locale]string{ko: "가사"},
+			Description:              "현재 곡 또는 검색한 곡의 가사를 표시합니다",
+			DescriptionLocalizations: map[discord.Locale]string{ko: "현재 곡 또는 검색한 곡의 가사를 표시합니다"},
+			DMPermission:             &dmPerm,
+			Options: []discord.ApplicationCommandOption{
+				discord.ApplicationCommandOptionString{
+					Name:                     "query",
+					NameLocalizations:        map[discord.Locale]string{ko: "검색어"},
+					Description:              "가사를 찾을 곡 (비우면 현재 곡)",
+					DescriptionLocalizations: map[discord.Locale]string{ko: "가사를 찾을 곡 (비우면 현재 곡)"},
+				},
+			},
+		},
+		discord.SlashCommandCreate{
+			Name:                     "playlist",
+			NameLocalizations:        map[discord.Locale]string{ko: "플레이리스트"},
+			Description:              "개인/서버 플레이리스트를 관리합니다",
+			DescriptionLocalizations: map[discord.Locale]string{ko: "개인/서버 플레이리스트를 관리합니다"},
+			DMPermission:             &dmPerm,
+			Options: []discord.ApplicationCommandOption{
+				discord.ApplicationCommandOptionSubCommand{
+					Name:                     "save",
+					NameLocalizations:        map[discord.Locale]string{ko: "저장"},
+					Description:              "현재 곡과 대기열을 플레이리스트로 저장합니다",
+					DescriptionLocalizations: map[discord.Locale]string{ko: "현재 곡과 대기열을 플레이리스트로 저장합니다"},
+					Options:                  []discord.ApplicationCommandOption{playlistNameOption, playlistScopeOption},
+				},
+				discord.ApplicationCommandOptionSubCommand{
+					Name:                     "load",
+					NameLocalizations:        map[discord.Locale]string{ko: "불러오기"},
+					Description:              "플레이리스트를 대기열에 추가합니다",
+					DescriptionLocalizations: map[discord.Locale]string{ko: "플레이리스트를 대기열에 추가합니다"},
+					Options:                  []discord.ApplicationCommandOption{playlistNameOption, playlistScopeOption},
+				},
+				discord.ApplicationCommandOptionSubCommand{
+					Name:                     "list",
+					NameLocalizations:        map[discord.Locale]string{ko: "목록"},
+					Description:              "플레이리스트 목록 또는 곡 목록을 표시합니다",
+					DescriptionLocalizations: map[discord.Locale]string{ko: "플레이리스트 목록 또는 곡 목록을 표시합니다"},
+					Options: []discord.ApplicationCommandOption{
+						discord.ApplicationCommandOptionString{
+							Name:                     "name",
+							NameLocalizations:        map[discord.Locale]string{ko: "이름"},
+							Description:              "곡 목록을 볼 플레이리스트 (비우면 전체 목록)",
+							DescriptionLocalizations: map[discord.Locale]string{ko: "곡 목록을 볼 플레이리스트 (비우면 전체 목록)"},
+						},
+						playlistScopeOption,
+					},
+				},
+				discord.ApplicationCommandOptionSubCommand{
+					Name:                     "delete",
+					NameLocalizations:        map[discord.Locale]string{ko: "삭제"},
+					Description:              "플레이리스트를 삭제합니다",
+					DescriptionLocalizations: map[discord.Locale]string{ko: "플레이리스트를 삭제합니다"},
+					Options:                  []discord.ApplicationCommandOption{playlistNameOption, playlistScopeOption},
+				},
+				discord.ApplicationCommandOptionSubCommand{
+					Name:                     "add",
+					NameLocalizations:        map[discord.Locale]string{ko: "추가"},
+					Description:              "플레이리스트에 곡을 추가합니다 (검색어를 비우면 현재 곡)",
+					DescriptionLocalizations: map[discord.Locale]string{ko: "플레이리스트에 곡을 추가합니다 (검색어를 비우면 현재 곡)"},
+					Options: []discord.ApplicationCommandOption{
+						playlistNameOption,
+						discord.ApplicationCommandOptionString{
+							Name:                     "query",
+							NameLocalizations:        map[discord.Locale]string{ko: "검색어"},
+							Description:              "추가할 곡의 검색어 또는 URL",
+							DescriptionLocalizations: map[discord.Locale]string{ko: "추가할 곡의 검색어 또는 URL"},
+						},
+						playlistScopeOption,
+					},
+				},
+				discord.ApplicationCommandOptionSubCommand{
+					Name:                     "remove",
+					NameLocalizations:        map[discord.Locale]string{ko: "곡삭제"},
+					Description:              "플레이리스트에서 곡을 삭제합니다",
+					DescriptionLocalizations: map[discord.Locale]string{ko: "플레이리스트에서 곡을 삭제합니다"},
+					Options: []discord.ApplicationCommandOption{
+						playlistNameOption,
+						discord.ApplicationCommandOptionInt{
+							Name:                     "position",
+							NameLocalizations:        map[discord.Locale]string{ko: "위치"},
+							Description:              "삭제할 곡 번호",
+							DescriptionLocalizations: map[discord.Locale]string{ko: "삭제할 곡 번호"},
+							Required:                 true,
+							MinValue:                 intPtr(1),
+						},
+						playlistScopeOption,
+					},
+				},
+				discord.ApplicationCommandOptionSubCommand{
+					Name:                     "rename",
+					NameLocalizations:        map[discord.Locale]string{ko: "이름변경"},
+					Description:              "플레이리스트 이름을 바꿉니다",
+					DescriptionLocalizations: map[discord.Locale]string{ko: "플레이리스트 이름을 바꿉니다"},
+					Options: []discord.ApplicationCommandOption{
+						playlistNameOption,
+						discord.ApplicationCommandOptionString{
+							Name:                     "new_name",
+							NameLocalizations:        map[discord.Locale]string{ko: "새이름"},
+							Description:              "새 이름",
+							DescriptionLocalizations: map[discord.Locale]string{ko: "새 이름"},
+							Required:                 true,
+							MaxLength:                intPtr(50),
+						},
+						playlistScopeOption,
+					},
+				},
+			},
+		},
+		discord.SlashCommandCreate{
+			Name:                     "source",
+			NameLocalizations:        map[discord.Locale]string{ko: "검색소스"},
+			Description:              "서버 기본 검색 소스를 설정합니다",
+			DescriptionLocalizations: map[discord.Locale]string{ko: "서버 기본 검색 소스를 설정합니다"},
+			DMPermission:             &dmPerm,
+			DefaultMemberPermissions: json.NewNullablePtr(discord.PermissionManageGuild),
+			Options: []discord.ApplicationCommandOption{
+				discord.ApplicationCommandOptionString{
+					Name:                     "source",
+					NameLocalizations:        map[discord.Locale]string{ko: "소스"},
+					Description:              "/play에서 기본으로 검색할 플랫폼",
+					DescriptionLocalizations: map[discord.Locale]string{ko: "/play에서 기본으로 검색할 플랫폼"},
+					Required:                 true,
+					Choices:                  sourceChoices(),
+				},
+			},
+		},
+		discord.SlashCommandCreate{
+			Name:                     "settings",
+			NameLocalizations:        map[discord.Locale]string{ko: "설정"},
+			Description:              "서버 설정을 확인하고 변경합니다",
+			DescriptionLocalizations: map[discord.Locale]string{ko: "서버 설정을 확인하고 변경합니다"},
+			DMPermission:             &dmPerm,
+			DefaultMemberPermissions: json.NewNullablePtr(discord.PermissionManageGuild),
+			Options: []discord.ApplicationCommandOption{
+				discord.ApplicationCommandOptionSubCommand{
+					Name:                     "view",
+					NameLocalizations:        map[discord.Locale]string{ko: "보기"},
+					Description:              "현재 서버 설정을 표시합니다",
+					DescriptionLocalizations: map[discord.Locale]string{ko: "현재 서버 설정을 표시합니다"},
+				},
+				discord.ApplicationCommandOptionSubCommand{
+					Name:                     "set",
+					NameLocalizations:        map[discord.Locale]string{ko: "변경"},
+					Description:              "입력한 항목만 변경합니다",
+					DescriptionLocalizations: map[discord.Locale]string{ko: "입력한 항목만 변경합니다"},
+					Options: []discord.ApplicationCommandOption{
+						discord.ApplicationCommandOptionInt{
+							Name:                     "volume",
+							NameLocalizations:        map[discord.Locale]string{ko: "볼륨"},
+							Description:              "새로 재생을 시작할 때의 볼륨 (1-100)",
+							DescriptionLocalizations: map[discord.Locale]string{ko: "새로 재생을 시작할 때의 볼륨 (1-100)"},
+							MinValue:                 intPtr(1),
+							MaxValue:                 intPtr(100),
+						},
+						discord.ApplicationCommandOptionInt{
+							Name:                     "idle_timeout",
+							NameLocalizations:        map[discord.Locale]string{ko: "자동퇴장"},
+							Description:              "재생이 끝난 뒤 퇴장할 때까지의 시간(분), 0이면 퇴장하지 않음",
+							DescriptionLocalizations: map[discord.Locale]string{ko: "재생이 끝난 뒤 퇴장할 때까지의 시간(분), 0이면 퇴장하지 않음"},
+							MinValue:                 intPtr(0),
+							MaxValue:                 intPtr(1440),
+						},
+						discord.ApplicationCommandOptionChannel{
+							Name:                     "announce_channel",
+							NameLocalizations:        map[discord.Locale]string{ko: "안내채널"},
+							Description:              "Now Playing 메시지를 보낼 채널",
+							DescriptionLocalizations: map[discord.Locale]string{ko: "Now Playing 메시지를 보낼 채널"},
+							ChannelTypes:             []discord.ChannelType{discord.ChannelTypeGuildText},
+						},
+						discord.ApplicationCommandOptionInt{
+							Name:                     "max_queue",
+							NameLocalizations:        map[discord.Locale]string{ko: "최대대기열"},
+							Description:              "대기열 최대 곡 수, 0이면 제한 없음",
+							DescriptionLocalizations: map[discord.Locale]string{ko: "대기열 최대 곡 수, 0이면 제한 없음"},
+							MinValue:                 intPtr(0),
+						},
+						discord.ApplicationCommandOptionInt{
+							Name:                     "max_duration",
+							NameLocalizations:        map[discord.Locale]string{ko: "최대길이"},
+							Description:              "추가할 수 있는 곡의 최대 길이(분), 0이면 제한 없음",
+							DescriptionLocalizations: map[discord.Locale]string{ko: "추가할 수 있는 곡의 최대 길이(분), 0이면 제한 없음"},
+							MinValue:                 intPtr(0),
+						},
+						discord.ApplicationCommandOptionString{
+							Name:                     "search_source",
+							NameLocalizations:        map[discord.Locale]string{ko: "검색소스"},
+							Description:              "/play에서 기본으로 검색할 플랫폼",
+							DescriptionLocalizations: map[discord.Locale]string{ko: "/play에서 기본으로 검색할 플랫폼"},
+							Choices:                  sourceChoices(),
+						},
+						discord.ApplicationCommandOptionBool{
+							Name:                     "persistent_np",
+							NameLocalizations:        map[discord.Locale]string{ko: "현재곡고정"},
+							Description:              "Now Playing 메시지를 곡마다 새로 보내지 않고 하나를 계속 수정",
+							DescriptionLocalizations: map[discord.Locale]string{ko: "Now Playing 메시지를 곡마다 새로 보내지 않고 하나를 계속 수정"},
+						},
+						discord.ApplicationCommandOptionChannel{
+							Name:                     "request_channel",
+							NameLocalizations:        map[discord.Locale]string{ko: "신청채널"},
+							Description:              "메시지를 보내면 바로 재생하는 음악 신청 채널",
+							DescriptionLocalizations: map[discord.Locale]string{ko: "메시지를 보내면 바로 재생하는 음악 신청 채널"},
+							ChannelTypes:             []discord.ChannelType{discord.ChannelTypeGuildText},
+						},
+					},
+				},
+				discord.ApplicationCommandOptionSubCommand{
+					Name:                     "reset",
+					NameLocalizations:        map[discord.Locale]string{ko: "초기화"},
+					Description:              "설정을 기본값으로 되돌립니다",
+					DescriptionLocalizations: map[discord.Locale]string{ko: "설정을 기본값으로 되돌립니다"},
+					Options: []discord.ApplicationCommandOption{
+						discord.ApplicationCommandOptionString{
+							Name:                     "setting",
+							NameLocalizations:        map[discord.Locale]string{ko: "항목"},
+							Description:              "되돌릴 항목",
+							DescriptionLocalizations: map[discord.Locale]string{ko: "되돌릴 항목"},
+							Required:                 true,
+							Choices: []discord.ApplicationCommandOptionChoiceString{
+								{Name: "전체", Value: "all"},
+								{Name: "기본 볼륨", Value: "volume"},
+								{Name: "자동 퇴장", Value: "idle_timeout"},
+								{Name: "안내 채널", Value: "announce_channel"},
+								{Name: "최대 대기열", Value: "max_queue"},
+								{Name: "최대 곡 길이", Value: "max_duration"},
+								{Name: "기본 검색 소스", Value: "search_source"},
+								{Name: "Now Playing 메시지 고정", Value: "persistent_np"},
+								{Name: "음악 신청 채널", Value: "request_channel"},
+							},
+						},
+					},
+				},
+			},
+		},
+		discord.SlashCommandCreate{
+			Name:                     "dj",
+			NameLocalizations:        map[discord.Locale]string{ko: "디제이"},
+			Description:              "DJ 역할과 명령어별 권한을 설정합니다",
+			DescriptionLocalizations: map[discord.Locale]string{ko: "DJ 역할과 명령어별 권한을 설정합니다"},
+			DMPermission:             &dmPerm,
+			DefaultMemberPermissions: json.NewNullablePtr(discord.PermissionManageGuild),
+			Options: []discord.ApplicationCommandOption{
+				discord.ApplicationCommandOptionSubCommand{
+					Name:                     "role",
+					NameLocalizations:        map[discord.Locale]string{ko: "역할"},
+					Description:              "DJ 역할을 설정합니다 (비우면 해제)",
+					DescriptionLocalizations: map[discord.Locale]string{ko: "DJ 역할을 설정합니다 (비우면 해제)"},
+					Options: []discord.ApplicationCommandOption{
+						discord.ApplicationCommandOptionRole{
+							Name:                     "role",
+							NameLocalizations:        map[discord.Locale]string{ko: "역할"},
+							Description:              "DJ 역할",
+							DescriptionLocalizations: map[discord.Locale]string{ko: "DJ 역할"},
+						},
+					},
+				},
+				discord.ApplicationCommandOptionSubCommand{
+					Name:                     "allow",
+					NameLocalizations:        map[discord.Locale]string{ko: "허용"},
+					Description:              "명령어/버튼을 사용할 수 있는 역할을 추가합니다",
+					DescriptionLocalizations: map[discord.Locale]string{ko: "명령어/버튼을 사용할 수 있는 역할을 추가합니다"},
+					Options: []discord.ApplicationCommandOption{
+						discord.ApplicationCommandOptionString{
+							Name:                     "action",
+							NameLocalizations:        map[discord.Locale]string{ko: "대상"},
+							Description:              "명령어 이름 또는 버튼 ID (예: skip, np_skip)",
+							DescriptionLocalizations: map[discord.Locale]string{ko: "명령어 이름 또는 버튼 ID (예: skip, np_skip)"},
+							Required:                 true,
+						},
+						discord.ApplicationCommandOptionRole{
+							Name:                     "role",
+							NameLocalizations:        map[discord.Locale]string{ko: "역할"},
+							Description:              "허용할 역할",
+							DescriptionLocalizations: map[discord.Locale]string{ko: "허용할 역할"},
+							Required:                 true,
+						},
+					},
+				},
+				discord.ApplicationCommandOptionSubCommand{
+					Name:                     "reset",
+					NameLocalizations:        map[discord.Locale]string{ko: "초기화"},
+					Description:              "명령어/버튼 권한을 기본값으로 되돌립니다",
+					DescriptionLocalizations: map[discord.Locale]string{ko: "명령어/버튼 권한을 기본값으로 되돌립니다"},
+					Options: []discord.ApplicationCommandOption{
+						discord.ApplicationCommandOptionString{
+							Name:                     "action",
+							NameLocalizations:        map[discord.Locale]string{ko: "대상"},
+							Description:              "명령어 이름 또는 버튼 ID",
+							DescriptionLocalizations: map[discord.Locale]string{ko: "명령어 이름 또는 버튼 ID"},
+							Required:                 true,
+						},
+					},
+				},
+				discord.ApplicationCommandOptionSubCommand{
+					Name:                     "voteskip",
+					NameLocalizations:        map[discord.Locale]string{ko: "투표스킵"},
+					Description:              "DJ가 아닌 멤버의 스킵을 투표로 처리합니다",
+					DescriptionLocalizations: map[discord.Locale]string{ko: "DJ가 아닌 멤버의 스킵을 투표로 처리합니다"},
+					Options: []discord.ApplicationCommandOption{
+						discord.ApplicationCommandOptionBool{
+							Name:                     "enabled",
+							NameLocalizations:        map[discord.Locale]string{ko: "사용"},
+							Description:              "투표 스킵 사용 여부",
+							DescriptionLocalizations: map[discord.Locale]string{ko: "투표 스킵 사용 여부"},
+							Required:                 true,
+						},
+						discord.ApplicationCommandOptionInt{
+							Name:                     "percent",
+							NameLocalizations:        map[discord.Locale]string{ko: "비율"},
+							Description:              "스킵에 필요한 청취자 비율 (1-100, 기본 50)",
+							DescriptionLocalizations: map[discord.Locale]string{ko: "스킵에 필요한 청취자 비율 (1-100, 기본 50)"},
+							MinValue:                 intPtr(1),
+							MaxValue:                 intPtr(100),
+						},
+					},
+				},
+				discord.ApplicationCommandOptionSubCommand{
+					Name:                     "show",
+					NameLocalizations:        map[discord.Locale]string{ko: "보기"},
+					Description:              "현재 권한 설정을 표시합니다",
+					DescriptionLocalizations: map[discord.Locale]string{ko: "현재 권한 설정을 표시합니다"},
+				},
+			},
+		},
+		discord.SlashCommandCreate{
+			Name:                     "api",
+			NameLocalizations:        map[discord.Locale]string{ko: "api"},
+			Description:              "웹 컨트롤러용 HTTP API 토큰을 관리합니다",
+			DescriptionLocalizations: map[discord.Locale]string{ko: "웹 컨트롤러용 HTTP API 토큰을 관리합니다"},
+			DMPermission:             &dmPerm,
+			DefaultMemberPermissions: json.NewNullablePtr(discord.PermissionManageGuild),
+			Options: []discord.ApplicationCommandOption{
+				discord.ApplicationCommandOptionSubCommand{
+					Name:                     "token",
+					NameLocalizations:        map[discord.Locale]string{ko: "발급"},
+					Description:              "이 서버의 API 토큰을 새로 발급합니다 (기존 토큰은 폐기)",
+					DescriptionLocalizations: map[discord.Locale]string{ko: "이 서버의 API 토큰을 새로 발급합니다 (기존 토큰은 폐기)"},
+				},
+				discord.ApplicationCommandOptionSubCommand{
+					Name:                     "revoke",
+					NameLocalizations:        map[discord.Locale]string{ko: "폐기"},
+					Description:              "이 서버의 API 토큰을 폐기합니다",
+					DescriptionLocalizations: map[discord.Locale]string{ko: "이 서버의 API 토큰을 폐기합니다"},
+				},
+			},
+		},
 		discord.SlashCommandCreate{
 			Name:                     "help",
 			NameLocalizations:        map[discord.Locale]string{ko: "도움말"},
@@ -173,6 +767,27 @@ var (
 	}
 )
 
+var (
+	playlistNameOption = discord.ApplicationCommandOptionString{
+		Name:                     "name",
+		NameLocalizations:        map[discord.Locale]string{ko: "이름"},
+		Description:              "플레이리스트 이름",
+		DescriptionLocalizations: map[discord.Locale]string{ko: "플레이리스트 이름"},
+		Required:                 true,
+		MaxLength:                intPtr(50),
+	}
+	playlistScopeOption = discord.ApplicationCommandOptionString{
+		Name:                     "scope",
+		NameLocalizations:        map[discord.Locale]string{ko: "범위"},
+		Description:              "개인 또는 서버 플레이리스트 (기본: 개인)",
+		DescriptionLocalizations: map[discord.Locale]string{ko: "개인 또는 서버 플레이리스트 (기본: 개인)"},
+		Choices: []discord.ApplicationCommandOptionChoiceString{
+			{Name: "개인", Value: "user"},
+			{Name: "서버", Value: "guild"},
+		},
+	}
+)
+
 type HelpEntry struct {
 	Command     string
 	Korean      string
@@ -182,3 +797,20 @@ type HelpEntry struct {
 func intPtr(v int) *int {
 	return &v
 }
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+// sourceChoices는 검색 소스 선택지입니다. 플러그인이 필요한 소스는 이름에 표시합니다.
+func sourceChoices() []discord.ApplicationCommandOptionChoiceString {
+	choices := make([]discord.ApplicationCommandOptionChoiceString, 0, len(search.Sources))
+	for _, s := range search.Sources {
+		name := s.Label
+		if s.Plugin {
+			name += " (플러그인 필요)"
+		}
+		choices = append(choices, discord.ApplicationCommandOptionChoiceString{Name: name, Value: s.Name})
+	}
+	return choices
+}
@@ -2,13 +2,21 @@ package embed
 
 import (
 	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgolink/v3/lavalink"
 	"github.com/uzih05/discord-music-bot/internal/command"
+	"github.com/uzih05/discord-music-bot/internal/lyrics"
+	"github.com/uzih05/discord-music-bot/internal/permission"
 	"github.com/uzih05/discord-music-bot/internal/player"
+	"github.com/uzih05/discord-music-bot/internal/playlist"
 	"github.com/uzih05/discord-music-bot/internal/search"
+	"github.com/uzih05/discord-music-bot/internal/settings"
 )
 
 const Color = 0x1DB954
@@ -30,6 +38,9 @@ func NowPlayingEmbed(track lavalink.Track, gp *player.GuildPlayer, position lava
 	repeatMode := gp.Repeat
 	volume := gp.Volume
 	queueLen := len(gp.Queue)
+	skipVotes := len(gp.SkipVotes)
+	skipVotesNeeded := gp.SkipVotesNeeded
+	filters := gp.Filters.Labels()
 	gp.Mu.Unlock()
 
 	builder := discord.NewEmbedBuilder().
@@ -40,6 +51,11 @@ func NowPlayingEmbed(track lavalink.Track, gp *player.GuildPlayer, position lava
 	if track.Info.Author != "" {
 		description += fmt.Sprintf("\n%s", track.Info.Author)
 	}
+	if player.IsAutoplay(track) {
+		description += "\n📻 자동 재생"
+	} else if requester := player.Requester(track); requester != 0 {
+		description += fmt.Sprintf("\n신청: <@%s>", requester)
+	}
 
 	if track.Info.IsStream {
 		description += "\n\n`LIVE`"
@@ -55,10 +71,17 @@ func NowPlayingEmbed(track lavalink.Track, gp *player.GuildPlayer, position lava
 	if track.Info.ArtworkURL != nil && *track.Info.ArtworkURL != "" {
 		builder.SetThumbnail(*track.Info.ArtworkURL)
 	}
+	builder.SetFooterText(search.TrackSource(track).String())
 
 	builder.AddField("볼륨", fmt.Sprintf("%d%%", volume), true)
 	builder.AddField("반복", repeatMode.String(), true)
 	builder.AddField("대기열", fmt.Sprintf("%d곡", queueLen), true)
+	if skipVotes > 0 {
+		builder.AddField("스킵 투표", fmt.Sprintf("%d/%d", skipVotes, skipVotesNeeded), true)
+	}
+	if len(filters) > 0 {
+		builder.AddField("필터", strings.Join(filters, ", "), false)
+	}
 
 	return builder.Build()
 }
@@ -89,13 +112,43 @@ func progressBar(position, total lavalink.Duration, length int) string {
 	return bar
 }
 
-func QueueEmbed(gp *player.GuildPlayer) discord.Embed {
+// QueuePageSize는 대기열 한 페이지에 표시할 곡 수입니다.
+const QueuePageSize = 10
+
+// QueueMessage는 대기열의 page번째 페이지와 페이지 이동 버튼, 곡 조작 선택 메뉴를 만듭니다.
+// position은 현재 곡의 재생 위치로, 남은 시간 계산에 사용됩니다.
+func QueueMessage(gp *player.GuildPlayer, page int, position lavalink.Duration) (discord.Embed, []discord.ContainerComponent) {
 	gp.Mu.Lock()
 	currentTrack := gp.CurrentTrack
-	queueLen := len(gp.Queue)
+	queue := slices.Clone(gp.Queue)
 	repeatMode := gp.Repeat
+	queueMode := gp.QueueMode
 	gp.Mu.Unlock()
 
+	totalPages := QueueTotalPages(len(queue))
+	page = min(max(page, 0), totalPages-1)
+
+	var remaining lavalink.Duration
+	live := false
+	if currentTrack != nil {
+		if currentTrack.Info.IsStream {
+			live = true
+		} else {
+			remaining += max(currentTrack.Info.Length-position, 0)
+		}
+	}
+	for _, track := range queue {
+		if track.Info.IsStream {
+			live = true
+			continue
+		}
+		remaining += track.Info.Length
+	}
+	remainingText := FormatDuration(remaining)
+	if live {
+		remainingText += " + LIVE"
+	}
+
 	builder := discord.NewEmbedBuilder().
 		SetTitle("대기열").
 		SetColor(Color)
@@ -103,35 +156,109 @@ func QueueEmbed(gp *player.GuildPlayer) discord.Embed {
 	description := ""
 
 	if currentTrack != nil {
-		description += fmt.Sprintf("**현재 재생:** [%s](%s) `%s`\n\n",
+		description += fmt.Sprintf("**현재 재생:** [%s](%s) `%s`%s\n\n",
 			currentTrack.Info.Title,
 			*currentTrack.Info.URI,
-			FormatDuration(currentTrack.Info.Length))
+			FormatDuration(currentTrack.Info.Length),
+			requestedBy(*currentTrack))
 	} else {
 		description += "현재 재생 중인 곡이 없습니다.\n\n"
 	}
 
-	if queueLen == 0 {
+	start := page * QueuePageSize
+	end := min(start+QueuePageSize, len(queue))
+	if len(queue) == 0 {
 		description += "대기열이 비어있습니다."
-	} else {
-		tracks := gp.QueueList(10)
-		for i, track := range tracks {
-			duration := FormatDuration(track.Info.Length)
-			if track.Info.IsStream {
-				duration = "LIVE"
-			}
-			description += fmt.Sprintf("`%d.` [%s](%s) `%s`\n",
-				i+1, track.Info.Title, *track.Info.URI, duration)
-		}
-		if queueLen > 10 {
-			description += fmt.Sprintf("\n... 외 %d곡", queueLen-10)
+	}
+	for i := start; i < end; i++ {
+		track := queue[i]
+		duration := FormatDuration(track.Info.Length)
+		if track.Info.IsStream {
+			duration = "LIVE"
 		}
+		description += fmt.Sprintf("`%d.` [%s](%s) `%s`%s\n",
+			i+1, track.Info.Title, *track.Info.URI, duration, requestedBy(track))
 	}
 
 	builder.SetDescription(description)
-	builder.SetFooterText(fmt.Sprintf("총 %d곡 | 반복: %s", queueLen, repeatMode))
+	builder.SetFooterText(fmt.Sprintf("페이지 %d/%d | 총 %d곡 | 남은 시간: %s | 반복: %s | 모드: %s",
+		page+1, totalPages, len(queue), remainingText, repeatMode, queueMode))
 
-	return builder.Build()
+	components := []discord.ContainerComponent{
+		discord.NewActionRow(
+			discord.NewSecondaryButton("⏮", fmt.Sprintf("queue_first:%d", page)).WithDisabled(page == 0),
+			discord.NewSecondaryButton("◀ 이전", fmt.Sprintf("queue_prev:%d", page)).WithDisabled(page == 0),
+			discord.NewSecondaryButton(fmt.Sprintf("%d/%d", page+1, totalPages), fmt.Sprintf("queue_jump:%d", page)).WithDisabled(totalPages == 1),
+			discord.NewSecondaryButton("다음 ▶", fmt.Sprintf("queue_next:%d", page)).WithDisabled(page >= totalPages-1),
+			discord.NewSecondaryButton("⏭", fmt.Sprintf("queue_last:%d", page)).WithDisabled(page >= totalPages-1),
+		),
+	}
+
+	if start < end {
+		options := make([]discord.StringSelectMenuOption, 0, end-start)
+		for i := start; i < end; i++ {
+			options = append(options, discord.NewStringSelectMenuOption(
+				truncate(fmt.Sprintf("%d. %s", i+1, queue[i].Info.Title), 100),
+				QueueOptionValue(i+1, queue[i]),
+			).WithDescription(truncate(queue[i].Info.Author, 100)))
+		}
+		components = append(components,
+			discord.NewActionRow(discord.NewStringSelectMenu(fmt.Sprintf("queue_playnext:%d", page), "⏫ 다음에 재생할 곡 선택", options...)),
+			discord.NewActionRow(discord.NewStringSelectMenu(fmt.Sprintf("queue_remove:%d", page), "🗑 삭제할 곡 선택", options...)),
+			discord.NewActionRow(discord.NewStringSelectMenu(fmt.Sprintf("queue_move:%d", page), "↕ 이동할 곡 선택", options...)),
+		)
+	}
+
+	return builder.Build(), components
+}
+
+// QueueTotalPages는 대기열 길이에 대한 페이지 수입니다 (최소 1).
+func QueueTotalPages(queueLen int) int {
+	return max((queueLen+QueuePageSize-1)/QueuePageSize, 1)
+}
+
+// QueueOptionValue는 선택 메뉴 값으로 "위치|식별자"를 만듭니다.
+// 메뉴를 띄운 뒤 대기열이 바뀌었는지 식별자로 확인하며, 모달 custom ID에도
+// 들어가므로 식별자는 앞 64바이트만 사용합니다.
+func QueueOptionValue(pos int, track lavalink.Track) string {
+	identifier := track.Info.Identifier
+	if len(identifier) > 64 {
+		identifier = identifier[:64]
+	}
+	return fmt.Sprintf("%d|%s", pos, identifier)
+}
+
+// ParseQueueOptionValue는 QueueOptionValue로 만든 값을 해석합니다.
+func ParseQueueOptionValue(value string) (int, string, bool) {
+	posStr, identifier, ok := strings.Cut(value, "|")
+	if !ok {
+		return 0, "", false
+	}
+	pos, err := strconv.Atoi(posStr)
+	if err != nil {
+		return 0, "", false
+	}
+	return pos, identifier, true
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
+
+// requestedBy는 신청자 멘션 접미사를 만듭니다. 신청자 정보가 없으면 빈 문자열입니다.
+func requestedBy(track lavalink.Track) string {
+	if player.IsAutoplay(track) {
+		return " · 📻 자동 재생"
+	}
+	requester := player.Requester(track)
+	if requester == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" · <@%s>", requester)
 }
 
 func SearchResultsMessage(ps *search.PendingSearch) (discord.Embed, []discord.ContainerComponent) {
@@ -148,11 +275,12 @@ func SearchResultsMessage(ps *search.PendingSearch) (discord.Embed, []discord.Co
 		if track.Info.IsStream {
 			duration = "LIVE"
 		}
-		description += fmt.Sprintf("`%d.` **%s**\n%s · `%s`\n\n",
+		description += fmt.Sprintf("`%d.` **%s**\n%s · `%s` · %s\n\n",
 			ps.Page*search.PageSize+i+1,
 			track.Info.Title,
 			track.Info.Author,
-			duration)
+			duration,
+			search.TrackSource(track))
 	}
 	builder.SetDescription(description)
 
@@ -163,12 +291,14 @@ func SearchResultsMessage(ps *search.PendingSearch) (discord.Embed, []discord.Co
 		}
 	}
 
-	var selectButtons []discord.InteractiveComponent
-	for i := range tracks {
-		selectButtons = append(selectButtons, discord.NewPrimaryButton(
-			fmt.Sprintf("%d", ps.Page*search.PageSize+i+1),
-			fmt.Sprintf("search_select:%d", i),
-		))
+	// 여러 곡을 한 번에 고를 수 있도록 선택 메뉴 사용 (값은 전체 결과 기준 인덱스)
+	options := make([]discord.StringSelectMenuOption, 0, len(tracks))
+	for i, track := range tracks {
+		index := ps.Page*search.PageSize + i
+		options = append(options, discord.NewStringSelectMenuOption(
+			truncate(fmt.Sprintf("%d. %s", index+1, track.Info.Title), 100),
+			strconv.Itoa(index),
+		).WithDescription(truncate(track.Info.Author, 100)))
 	}
 
 	prevDisabled := ps.Page == 0
@@ -177,13 +307,19 @@ func SearchResultsMessage(ps *search.PendingSearch) (discord.Embed, []discord.Co
 	navButtons := []discord.InteractiveComponent{
 		discord.NewSecondaryButton("◀ 이전", "search_prev").WithDisabled(prevDisabled),
 		discord.NewSecondaryButton("다음 ▶", "search_next").WithDisabled(nextDisabled),
+		discord.NewPrimaryButton("이 페이지 전체 추가", "search_all").WithDisabled(len(tracks) == 0),
 		discord.NewDangerButton("취소", "search_cancel"),
 	}
 
-	components := []discord.ContainerComponent{
-		discord.NewActionRow(selectButtons...),
-		discord.NewActionRow(navButtons...),
+	var components []discord.ContainerComponent
+	if len(options) > 0 {
+		components = append(components, discord.NewActionRow(
+			discord.NewStringSelectMenu("search_select", "재생할 곡 선택 (여러 곡 선택 가능)", options...).
+				WithMinValues(1).
+				WithMaxValues(len(options)),
+		))
 	}
+	components = append(components, discord.NewActionRow(navButtons...))
 
 	return builder.Build(), components
 }
@@ -192,6 +328,8 @@ func NowPlayingButtons(gp *player.GuildPlayer) []discord.ContainerComponent {
 	gp.Mu.Lock()
 	volume := gp.Volume
 	repeatMode := gp.Repeat
+	historyLen := len(gp.History)
+	seekable := gp.CurrentTrack != nil && !gp.CurrentTrack.Info.IsStream
 	gp.Mu.Unlock()
 
 	var repeatLabel string
@@ -212,19 +350,366 @@ func NowPlayingButtons(gp *player.GuildPlayer) []discord.ContainerComponent {
 		discord.NewSecondaryButton("📜 대기열", "np_queue"),
 	}
 
+	controls := []discord.InteractiveComponent{
+		discord.NewSecondaryButton("⏮ 이전", "np_previous").WithDisabled(historyLen == 0),
+		discord.NewSecondaryButton("⏪ -10초", "np_rewind").WithDisabled(!seekable),
+		discord.NewSecondaryButton("⏩ +10초", "np_forward").WithDisabled(!seekable),
+	}
+
 	return []discord.ContainerComponent{
 		discord.NewActionRow(buttons...),
+		discord.NewActionRow(controls...),
 	}
 }
 
-func IdleEmbed() discord.Embed {
+// HistoryPageSize는 /history 한 페이지에 표시할 곡 수입니다.
+const HistoryPageSize = 10
+
+func HistoryMessage(gp *player.GuildPlayer, page int) (discord.Embed, []discord.ContainerComponent) {
+	tracks := gp.HistoryList()
+
+	totalPages := (len(tracks) + HistoryPageSize - 1) / HistoryPageSize
+	if totalPages == 0 {
+		totalPages = 1
+	}
+	page = min(max(page, 0), totalPages-1)
+
+	builder := discord.NewEmbedBuilder().
+		SetTitle("재생 기록").
+		SetColor(Color).
+		SetFooterText(fmt.Sprintf("페이지 %d/%d | 최근 %d곡", page+1, totalPages, len(tracks)))
+
+	description := ""
+	if len(tracks) == 0 {
+		description = "아직 재생한 곡이 없습니다."
+	}
+	start := page * HistoryPageSize
+	end := min(start+HistoryPageSize, len(tracks))
+	for i := start; i < end; i++ {
+		track := tracks[i]
+		duration := FormatDuration(track.Info.Length)
+		if track.Info.IsStream {
+			duration = "LIVE"
+		}
+		description += fmt.Sprintf("`%d.` [%s](%s) `%s`\n",
+			i+1, track.Info.Title, *track.Info.URI, duration)
+	}
+	builder.SetDescription(description)
+
+	components := []discord.ContainerComponent{
+		discord.NewActionRow(
+			discord.NewSecondaryButton("◀ 이전", fmt.Sprintf("history_prev:%d", page)).WithDisabled(page == 0),
+			discord.NewSecondaryButton("다음 ▶", fmt.Sprintf("history_next:%d", page)).WithDisabled(page >= totalPages-1),
+		),
+	}
+
+	return builder.Build(), components
+}
+
+// IdleEmbed는 대기 상태 메시지입니다. leave가 false면 자동 퇴장 안내를 생략합니다.
+func IdleEmbed(timeout time.Duration, leave bool) discord.Embed {
+	description := "재생 중인 곡이 없습니다.\n"
+	if leave {
+		description += fmt.Sprintf("%d분 후 자동으로 퇴장합니다.\n", int(timeout.Minutes()))
+	}
+	description += "\n`/play` 로 노래를 틀어주세요."
+
 	return discord.NewEmbedBuilder().
 		SetTitle("⏸ 대기 중").
-		SetDescription("재생 중인 곡이 없습니다.\n3분 후 자동으로 퇴장합니다.\n\n`/play` 로 노래를 틀어주세요.").
+		SetDescription(description).
+		SetColor(0x808080).
+		Build()
+}
+
+// RestartEmbed는 봇이 종료될 때 재생 중이던 채널에 남기는 안내입니다.
+func RestartEmbed() discord.Embed {
+	return discord.NewEmbedBuilder().
+		SetTitle("🔄 봇 재시작 중").
+		SetDescription("봇이 잠시 재시작합니다.\n다시 켜지면 같은 음성 채널에 접속해 듣던 곡과 대기열을 이어서 재생합니다.").
 		SetColor(0x808080).
 		Build()
 }
 
+// PanelMessage는 음악 신청 채널에 고정해 두고 계속 수정하는 패널입니다.
+// 재생 중이면 Now Playing과 다음 곡 목록을, 아니면 사용 안내를 보여줍니다.
+func PanelMessage(gp *player.GuildPlayer, track *lavalink.Track, position lavalink.Duration) ([]discord.Embed, []discord.ContainerComponent) {
+	guide := "이 채널에 노래 제목이나 URL을 입력하면 바로 재생합니다.\n먼저 음성 채널에 접속해주세요."
+	if track == nil {
+		return []discord.Embed{discord.NewEmbedBuilder().
+			SetTitle("🎶 음악 신청 채널").
+			SetColor(0x808080).
+			SetDescription("재생 중인 곡이 없습니다.\n\n" + guide).
+			Build()}, []discord.ContainerComponent{}
+	}
+
+	queue := gp.QueueList(QueuePageSize)
+	total := gp.QueueLen()
+	description := ""
+	if total == 0 {
+		description = "대기열이 비어있습니다."
+	}
+	for i, t := range queue {
+		duration := FormatDuration(t.Info.Length)
+		if t.Info.IsStream {
+			duration = "LIVE"
+		}
+		description += fmt.Sprintf("`%d.` %s `%s`%s\n", i+1, truncate(t.Info.Title, 80), duration, requestedBy(t))
+	}
+	if total > len(queue) {
+		description += fmt.Sprintf("\n외 %d곡", total-len(queue))
+	}
+
+	queueEmbed := discord.NewEmbedBuilder().
+		SetTitle("다음 곡").
+		SetColor(Color).
+		SetDescription(description).
+		SetFooterText(guide).
+		Build()
+
+	return []discord.Embed{NowPlayingEmbed(*track, gp, position), queueEmbed}, NowPlayingButtons(gp)
+}
+
+// SettingsEmbed는 /settings view에서 보여주는 길드 설정입니다.
+func SettingsEmbed(g settings.Guild) discord.Embed {
+	idle := "퇴장하지 않음"
+	if timeout, leave := g.IdleTimeout(); leave {
+		idle = fmt.Sprintf("%d분", int(timeout.Minutes()))
+	}
+
+	announce := "명령어를 사용한 채널"
+	if g.AnnounceChannelID != 0 {
+		announce = fmt.Sprintf("<#%s>", g.AnnounceChannelID)
+	}
+
+	maxQueue := "제한 없음"
+	if g.MaxQueueLength > 0 {
+		maxQueue = fmt.Sprintf("%d곡", g.MaxQueueLength)
+	}
+
+	maxDuration := "제한 없음"
+	if g.MaxTrackMinutes > 0 {
+		maxDuration = fmt.Sprintf("%d분", g.MaxTrackMinutes)
+	}
+
+	npMode := "곡마다 새로 보내기"
+	if g.PersistentNowPlaying {
+		npMode = "메시지 하나를 계속 수정"
+	}
+
+	request := "없음"
+	if g.RequestChannelID != 0 {
+		request = fmt.Sprintf("<#%s>", g.RequestChannelID)
+	}
+
+	source, _ := search.FindSource(g.SearchSource)
+
+	return discord.NewEmbedBuilder().
+		SetTitle("서버 설정").
+		SetColor(Color).
+		AddField("기본 볼륨", fmt.Sprintf("%d%%", g.Volume()), true).
+		AddField("자동 퇴장", idle, true).
+		AddField("안내 채널", announce, true).
+		AddField("최대 대기열", maxQueue, true).
+		AddField("최대 곡 길이", maxDuration, true).
+		AddField("기본 검색 소스", source.String(), true).
+		AddField("Now Playing 메시지", npMode, true).
+		AddField("음악 신청 채널", request, true).
+		SetFooter("/settings set으로 변경, /settings reset으로 기본값 복원", "").
+		Build()
+}
+
+func PermissionEmbed(g settings.Guild) discord.Embed {
+	description := "**DJ 역할:** "
+	if g.DJRoleID == 0 {
+		description += "없음 (모든 멤버 허용)"
+	} else {
+		description += fmt.Sprintf("<@&%s>", g.DJRoleID)
+	}
+
+	description += "\n\n**DJ 전용 (기본값)**\n`" + strings.Join(permission.Restricted, "` `") + "`"
+
+	if len(g.Permissions) > 0 {
+		actions := make([]string, 0, len(g.Permissions))
+		for action := range g.Permissions {
+			actions = append(actions, action)
+		}
+		sort.Strings(actions)
+
+		description += "\n\n**개별 설정**\n"
+		for _, action := range actions {
+			roles := make([]string, 0, len(g.Permissions[action]))
+			for _, roleID := range g.Permissions[action] {
+				roles = append(roles, fmt.Sprintf("<@&%s>", roleID))
+			}
+			description += fmt.Sprintf("`%s` → %s\n", action, strings.Join(roles, ", "))
+		}
+	}
+
+	if g.VoteSkip {
+		description += fmt.Sprintf("\n\n**투표 스킵:** 켜짐 (청취자의 %d%%)", g.SkipPercent())
+	} else {
+		description += "\n\n**투표 스킵:** 꺼짐"
+	}
+
+	description += "\n\n곡을 신청한 본인은 자기 곡을 스킵/삭제할 수 있으며, 봇과 단둘이 있을 때는 제한이 없습니다."
+
+	return discord.NewEmbedBuilder().
+		SetTitle("권한 설정").
+		SetColor(Color).
+		SetDescription(description).
+		Build()
+}
+
+// LyricsPageSize는 가사 한 페이지의 최대 바이트 수입니다.
+const LyricsPageSize = 2000
+
+// LyricsMessage는 가사의 page번째 페이지와 이동 버튼을 만듭니다.
+func LyricsMessage(track lavalink.Track, l *lyrics.Lyrics, page int) (discord.Embed, []discord.ContainerComponent) {
+	pages := l.Pages(LyricsPageSize)
+	page = min(max(page, 0), len(pages)-1)
+
+	builder := discord.NewEmbedBuilder().
+		SetTitle(truncate("가사: "+track.Info.Title, 256)).
+		SetColor(Color).
+		SetDescription(pages[page]).
+		SetFooterText(fmt.Sprintf("페이지 %d/%d | 제공: %s", page+1, len(pages), l.Source))
+	if track.Info.ArtworkURL != nil && *track.Info.ArtworkURL != "" {
+		builder.SetThumbnail(*track.Info.ArtworkURL)
+	}
+
+	components := []discord.ContainerComponent{
+		discord.NewActionRow(
+			discord.NewSecondaryButton("◀ 이전", fmt.Sprintf("lyrics_prev:%d", page)).WithDisabled(page == 0),
+			discord.NewSecondaryButton("다음 ▶", fmt.Sprintf("lyrics_next:%d", page)).WithDisabled(page >= len(pages)-1),
+		),
+	}
+	return builder.Build(), components
+}
+
+// liveLyricsContext는 실시간 가사에서 현재 줄 앞뒤로 보여줄 줄 수입니다.
+const liveLyricsContext = 5
+
+// LiveLyricsMessage는 싱크 가사에서 position에 해당하는 줄을 강조해 앞뒤 몇 줄만 보여줍니다.
+func LiveLyricsMessage(track lavalink.Track, l *lyrics.Lyrics, position lavalink.Duration) (discord.Embed, []discord.ContainerComponent) {
+	current := l.CurrentLine(position)
+	start := max(current-liveLyricsContext, 0)
+	end := min(max(current, 0)+liveLyricsContext+1, len(l.Lines))
+
+	description := ""
+	for i := start; i < end; i++ {
+		text := l.Lines[i].Text
+		if text == "" {
+			text = "♪"
+		}
+		if i == current {
+			description += fmt.Sprintf("**▶ %s**\n", text)
+		} else {
+			description += fmt.Sprintf("-# %s\n", text)
+		}
+	}
+
+	builder := discord.NewEmbedBuilder().
+		SetTitle(truncate("가사: "+track.Info.Title, 256)).
+		SetColor(Color).
+		SetDescription(description).
+		SetFooterText(fmt.Sprintf("%s / %s | 실시간 가사 | 제공: %s",
+			FormatDuration(position), FormatDuration(track.Info.Length), l.Source))
+	if track.Info.ArtworkURL != nil && *track.Info.ArtworkURL != "" {
+		builder.SetThumbnail(*track.Info.ArtworkURL)
+	}
+
+	components := []discord.ContainerComponent{
+		discord.NewActionRow(discord.NewSecondaryButton("전체 가사 보기", "lyrics_full:0")),
+	}
+	return builder.Build(), components
+}
+
+// eqBandLabels는 Lavalink 15밴드 이퀄라이저의 중심 주파수입니다.
+var eqBandLabels = [player.EqBands]string{
+	"25Hz", "40Hz", "63Hz", "100Hz", "160Hz", "250Hz", "400Hz", "630Hz",
+	"1kHz", "1.6kHz", "2.5kHz", "4kHz", "6.3kHz", "10kHz", "16kHz",
+}
+
+// FilterEmbed는 켜진 필터와 사용자 EQ의 밴드별 게인을 표시합니다.
+func FilterEmbed(state player.FilterState) discord.Embed {
+	description := "**필터:** "
+	if labels := state.Labels(); len(labels) > 0 {
+		description += strings.Join(labels, ", ")
+	} else {
+		description += "없음"
+	}
+
+	description += "\n\n**사용자 EQ**\n"
+	if state.Equalizer == nil {
+		description += "기본값 (모든 밴드 0)"
+	} else {
+		description += "```\n"
+		for band, gain := range state.Equalizer {
+			description += fmt.Sprintf("%2d %-6s %+.2f\n", band, eqBandLabels[band], gain)
+		}
+		description += "```"
+	}
+
+	return discord.NewEmbedBuilder().
+		SetTitle("오디오 필터").
+		SetColor(Color).
+		SetDescription(description).
+		Build()
+}
+
+// PlaylistListEmbed는 범위 안의 플레이리스트 목록을 표시합니다.
+func PlaylistListEmbed(scope playlist.Scope, lists []playlist.Playlist) discord.Embed {
+	description := ""
+	if len(lists) == 0 {
+		description = "저장된 플레이리스트가 없습니다. `/playlist save`로 현재 대기열을 저장하세요."
+	}
+	for _, p := range lists {
+		description += fmt.Sprintf("**%s** · %d곡 · `%s`", p.Name, len(p.Tracks), FormatDuration(p.Duration()))
+		if scope.Guild {
+			description += fmt.Sprintf(" · <@%s>", p.OwnerID)
+		}
+		description += "\n"
+	}
+
+	return discord.NewEmbedBuilder().
+		SetTitle(fmt.Sprintf("%s 플레이리스트", scope)).
+		SetColor(Color).
+		SetDescription(description).
+		SetFooterText(fmt.Sprintf("%d/%d개", len(lists), playlist.MaxPlaylists)).
+		Build()
+}
+
+// PlaylistEmbed는 플레이리스트의 곡 목록을 앞에서부터 최대 20곡까지 표시합니다.
+func PlaylistEmbed(scope playlist.Scope, p playlist.Playlist) discord.Embed {
+	const maxShown = 20
+
+	description := fmt.Sprintf("만든 사람: <@%s>\n\n", p.OwnerID)
+	for i, track := range p.Tracks[:min(len(p.Tracks), maxShown)] {
+		duration := FormatDuration(track.Info.Length)
+		if track.Info.IsStream {
+			duration = "LIVE"
+		}
+		description += fmt.Sprintf("`%d.` %s `%s`\n", i+1, trackLink(track), duration)
+	}
+	if len(p.Tracks) > maxShown {
+		description += fmt.Sprintf("\n... 외 %d곡", len(p.Tracks)-maxShown)
+	}
+
+	return discord.NewEmbedBuilder().
+		SetTitle(fmt.Sprintf("%s 플레이리스트: %s", scope, p.Name)).
+		SetColor(Color).
+		SetDescription(description).
+		SetFooterText(fmt.Sprintf("총 %d곡 | %s", len(p.Tracks), FormatDuration(p.Duration()))).
+		Build()
+}
+
+func trackLink(track lavalink.Track) string {
+	if track.Info.URI == nil {
+		return track.Info.Title
+	}
+	return fmt.Sprintf("[%s](%s)", track.Info.Title, *track.Info.URI)
+}
+
 func HelpEmbed() discord.Embed {
 	builder := discord.NewEmbedBuilder().
 		SetTitle("명령어 도움말").
@@ -236,7 +721,7 @@ func HelpEmbed() discord.Embed {
 	}
 
 	description += "---\n"
-	description += "Now Playing 메시지의 버튼으로도 볼륨, 스킵, 반복, 대기열을 조작할 수 있습니다."
+	description += "Now Playing 메시지의 버튼으로도 볼륨, 이전 곡, 스킵, 10초 이동, 반복, 대기열을 조작할 수 있습니다."
 
 	builder.SetDescription(description)
 	return builder.Build()
@@ -0,0 +1,63 @@
+package embed
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/disgoorg/disgolink/v3/lavalink"
+	"github.com/uzih05/discord-music-bot/internal/lyrics"
+)
+
+func TestLiveLyricsMessage(t *testing.T) {
+	l := &lyrics.Lyrics{Source: "테스트"}
+	for i := range 20 {
+		l.Lines = append(l.Lines, lyrics.Line{Start: lavalink.Duration(i+1) * lavalink.Second, Text: fmt.Sprintf("줄%d", i)})
+	}
+	l.Lines[3].Text = ""
+	track := lavalink.Track{Info: lavalink.TrackInfo{Title: "곡", Length: lavalink.Minute}}
+
+	tests := []struct {
+		name     string
+		position lavalink.Duration
+		current  string
+		first    string
+		last     string
+	}{
+		{name: "첫 줄 전", position: 0, current: "", first: "줄0", last: "줄5"},
+		{name: "첫 줄", position: lavalink.Second, current: "줄0", first: "줄0", last: "줄5"},
+		{name: "빈 줄은 음표로", position: 4 * lavalink.Second, current: "♪", first: "줄0", last: "줄8"},
+		{name: "가운데", position: 10500 * lavalink.Millisecond, current: "줄9", first: "줄4", last: "줄14"},
+		{name: "마지막 줄 이후", position: lavalink.Minute, current: "줄19", first: "줄14", last: "줄19"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, _ := LiveLyricsMessage(track, l, tt.position)
+			lines := strings.Split(strings.TrimSuffix(e.Description, "\n"), "\n")
+
+			var highlighted []string
+			for _, line := range lines {
+				if strings.HasPrefix(line, "**▶ ") {
+					highlighted = append(highlighted, lyricText(line))
+				}
+			}
+			switch {
+			case tt.current == "" && len(highlighted) != 0:
+				t.Errorf("강조된 줄 = %v, want 없음", highlighted)
+			case tt.current != "" && (len(highlighted) != 1 || highlighted[0] != tt.current):
+				t.Errorf("강조된 줄 = %v, want %q", highlighted, tt.current)
+			}
+
+			first, last := lyricText(lines[0]), lyricText(lines[len(lines)-1])
+			if first != tt.first || last != tt.last {
+				t.Errorf("표시한 범위 = %q ~ %q, want %q ~ %q", first, last, tt.first, tt.last)
+			}
+		})
+	}
+}
+
+// lyricText는 실시간 가사 한 줄에서 강조/흐림 표시를 떼어냅니다.
+func lyricText(line string) string {
+	line = strings.TrimPrefix(line, "-# ")
+	return strings.TrimSuffix(strings.TrimPrefix(line, "**▶ "), "**")
+}
@@ -0,0 +1,56 @@
+package lyrics
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/disgoorg/disgolink/v3/lavalink"
+)
+
+// FileProvider는 Dir 안의 가사 파일을 읽습니다. 외부 서비스 없이 테스트할 때 사용합니다.
+// 파일 이름은 트랙 식별자(예: dQw4w9WgXcQ.lrc) 또는 "아티스트 - 제목"이며,
+// .lrc 파일은 싱크 가사로, .txt 파일은 일반 가사로 읽습니다.
+type FileProvider struct {
+	Dir string
+}
+
+func (p *FileProvider) Lyrics(_ context.Context, track lavalink.Track) (*Lyrics, error) {
+	names := []string{track.Info.Identifier}
+	if track.Info.Author != "" {
+		names = append(names, track.Info.Author+" - "+track.Info.Title)
+	}
+	names = append(names, track.Info.Title)
+
+	for _, name := range names {
+		name, ok := fileName(name)
+		if !ok {
+			continue
+		}
+		for _, ext := range []string{".lrc", ".txt"} {
+			data, err := os.ReadFile(filepath.Join(p.Dir, name+ext))
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			l := ParseLRC(string(data))
+			l.Source = "로컬 파일"
+			return l, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// fileName은 트랙 정보를 Dir 안의 파일 이름으로 바꿉니다.
+// 경로 구분자가 들어간 제목이 Dir 밖을 가리키지 않도록 구분자를 바꾸고, 그래도 안전하지 않은 이름은 거부합니다.
+func fileName(name string) (string, bool) {
+	name = strings.NewReplacer("/", "_", "\\", "_", "\x00", "").Replace(name)
+	if name == "" || name == "." || name == ".." || !filepath.IsLocal(name) {
+		return "", false
+	}
+	return name, true
+}
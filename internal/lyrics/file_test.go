@@ -0,0 +1,85 @@
+package lyrics
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/disgoorg/disgolink/v3/lavalink"
+)
+
+func TestFileName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+		ok   bool
+	}{
+		{name: "dQw4w9WgXcQ", want: "dQw4w9WgXcQ", ok: true},
+		{name: "가수 - 제목", want: "가수 - 제목", ok: true},
+		{name: "AC/DC - Back in Black", want: "AC_DC - Back in Black", ok: true},
+		{name: "../secret", want: ".._secret", ok: true},
+		{name: `..\secret`, want: ".._secret", ok: true},
+		{name: "/etc/passwd", want: "_etc_passwd", ok: true},
+		{name: "", ok: false},
+		{name: ".", ok: false},
+		{name: "..", ok: false},
+		{name: "\x00", ok: false},
+	}
+	for _, tt := range tests {
+		got, ok := fileName(tt.name)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("fileName(%q) = %q, %v, want %q, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestFileProvider(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "lyrics")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	write := func(path, content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(filepath.Join(dir, "abc123.lrc"), "[00:01.00] 싱크 가사")
+	write(filepath.Join(dir, "가수 - 제목.txt"), "일반 가사")
+	write(filepath.Join(dir, "AC_DC - Thunder.txt"), "구분자 치환")
+	// Dir 밖의 파일은 읽으면 안 됨
+	write(filepath.Join(root, "secret.txt"), "비밀")
+
+	track := func(identifier, author, title string) lavalink.Track {
+		return lavalink.Track{Info: lavalink.TrackInfo{Identifier: identifier, Author: author, Title: title}}
+	}
+	tests := []struct {
+		name  string
+		track lavalink.Track
+		want  string
+		err   error
+	}{
+		{name: "트랙 ID", track: track("abc123", "가수", "제목"), want: "싱크 가사"},
+		{name: "아티스트 - 제목", track: track("zzz", "가수", "제목"), want: "일반 가사"},
+		{name: "경로 구분자 치환", track: track("zzz", "AC/DC", "Thunder"), want: "구분자 치환"},
+		{name: "../ 경로 탈출", track: track("../secret", "", "../secret"), err: ErrNotFound},
+		{name: "절대 경로", track: track(filepath.Join(root, "secret"), "", ""), err: ErrNotFound},
+		{name: "상위 디렉터리", track: track("..", "", ".."), err: ErrNotFound},
+		{name: "없는 곡", track: track("nope", "", "nope"), err: ErrNotFound},
+	}
+	p := &FileProvider{Dir: dir}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := p.Lyrics(context.Background(), tt.track)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Lyrics error = %v, want %v", err, tt.err)
+			}
+			if err == nil && l.Text != tt.want {
+				t.Errorf("Lyrics = %q, want %q", l.Text, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package lyrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/disgoorg/disgolink/v3/disgolink"
+	"github.com/disgoorg/disgolink/v3/lavalink"
+)
+
+// LavaLyrics는 Lavalink LavaLyrics 플러그인의 /v4/lyrics 엔드포인트로 가사를 가져옵니다.
+// Lavalink 서버에 LavaLyrics와 가사 소스 플러그인(LavaSrc 등)이 설치되어 있어야 합니다.
+type LavaLyrics struct {
+	// Node는 요청을 보낼 노드를 고릅니다. nil을 반환하면 ErrNoNode입니다.
+	Node func() disgolink.Node
+}
+
+var ErrNoNode = errors.New("사용 가능한 Lavalink 노드가 없습니다")
+
+type lavaLyricsResponse struct {
+	SourceName string `json:"sourceName"`
+	Provider   string `json:"provider"`
+	Text       string `json:"text"`
+	Lines      []struct {
+		Timestamp int64  `json:"timestamp"`
+		Line      string `json:"line"`
+	} `json:"lines"`
+}
+
+func (p *LavaLyrics) Lyrics(ctx context.Context, track lavalink.Track) (*Lyrics, error) {
+	node := p.Node()
+	if node == nil {
+		return nil, ErrNoNode
+	}
+
+	// Do가 노드 주소와 인증 헤더를 채우므로 경로만 지정
+	rq, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		"http://lavalink/v4/lyrics?skipTrackSource=false&track="+url.QueryEscape(track.Encoded), nil)
+	if err != nil {
+		return nil, err
+	}
+	rs, err := node.Rest().Do(rq)
+	if err != nil {
+		return nil, err
+	}
+	defer rs.Body.Close()
+
+	switch {
+	case rs.StatusCode == http.StatusNoContent, rs.StatusCode == http.StatusNotFound:
+		return nil, ErrNotFound
+	case rs.StatusCode >= http.StatusBadRequest:
+		body, _ := io.ReadAll(io.LimitReader(rs.Body, 512))
+		return nil, fmt.Errorf("LavaLyrics 요청 실패 (%d): %s", rs.StatusCode, body)
+	}
+
+	var res lavaLyricsResponse
+	if err := json.NewDecoder(rs.Body).Decode(&res); err != nil {
+		return nil, err
+	}
+	if res.Text == "" && len(res.Lines) == 0 {
+		return nil, ErrNotFound
+	}
+
+	l := &Lyrics{Source: res.Provider, Text: res.Text}
+	if l.Source == "" {
+		l.Source = res.SourceName
+	}
+	for _, line := range res.Lines {
+		l.Lines = append(l.Lines, Line{Start: lavalink.Duration(line.Timestamp), Text: line.Line})
+	}
+	return l, nil
+}
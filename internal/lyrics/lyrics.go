@@ -0,0 +1,138 @@
+package lyrics
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/disgoorg/disgolink/v3/lavalink"
+)
+
+// ErrNotFound는 제공자가 곡의 가사를 찾지 못했을 때 반환됩니다.
+var ErrNotFound = errors.New("가사를 찾을 수 없습니다")
+
+// Provider는 트랙의 가사를 가져옵니다.
+// 기본 구현은 Lavalink LavaLyrics 플러그인(LavaLyrics)이며, 로컬 파일(FileProvider)로 바꿀 수 있습니다.
+type Provider interface {
+	Lyrics(ctx context.Context, track lavalink.Track) (*Lyrics, error)
+}
+
+// Line은 시간 정보가 있는 가사 한 줄입니다.
+type Line struct {
+	Start lavalink.Duration
+	Text  string
+}
+
+type Lyrics struct {
+	// Source는 가사를 제공한 곳의 표시 이름입니다 (예: "MusixMatch").
+	Source string
+	Text   string
+	// Lines는 시작 시각 순으로 정렬된 싱크 가사이며, 없으면 비어 있습니다.
+	Lines []Line
+}
+
+func (l *Lyrics) Synced() bool {
+	return len(l.Lines) > 0
+}
+
+// CurrentLine은 position에서 부르고 있는 줄의 인덱스를 반환합니다. 첫 줄 전이면 -1입니다.
+func (l *Lyrics) CurrentLine(position lavalink.Duration) int {
+	return sort.Search(len(l.Lines), func(i int) bool {
+		return l.Lines[i].Start > position
+	}) - 1
+}
+
+// Pages는 가사를 줄 단위로 나눠 페이지마다 maxChars 이하가 되도록 합니다.
+func (l *Lyrics) Pages(maxChars int) []string {
+	text := l.Text
+	if text == "" {
+		lines := make([]string, len(l.Lines))
+		for i, line := range l.Lines {
+			lines[i] = line.Text
+		}
+		text = strings.Join(lines, "\n")
+	}
+
+	var pages []string
+	var page strings.Builder
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		if page.Len() > 0 && page.Len()+len(line)+1 > maxChars {
+			pages = append(pages, page.String())
+			page.Reset()
+		}
+		if len(line) > maxChars {
+			cut := maxChars
+			for cut > 0 && !utf8.RuneStart(line[cut]) {
+				cut--
+			}
+			line = line[:cut]
+		}
+		if page.Len() > 0 {
+			page.WriteByte('\n')
+		}
+		page.WriteString(line)
+	}
+	if page.Len() > 0 || len(pages) == 0 {
+		pages = append(pages, page.String())
+	}
+	return pages
+}
+
+var (
+	lrcTimestamp = regexp.MustCompile(`\[(\d+):(\d{1,2})(?:[.:](\d{1,3}))?\]`)
+	lrcOffset    = regexp.MustCompile(`^\[offset:\s*([+-]?\d+)\s*\]$`)
+)
+
+// ParseLRC는 LRC 형식("[01:23.45] 가사")을 해석합니다.
+// 시간 태그가 없는 줄은 Text에만 남고, 한 줄에 태그가 여러 개면 각 시각에 같은 줄을 넣습니다.
+// [offset:+500] 태그가 있으면 모든 시각을 그만큼(밀리초) 앞당깁니다.
+func ParseLRC(raw string) *Lyrics {
+	l := &Lyrics{}
+	var plain []string
+	var offset lavalink.Duration
+	for _, rawLine := range strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n") {
+		tags := lrcTimestamp.FindAllStringSubmatch(rawLine, -1)
+		text := strings.TrimSpace(lrcTimestamp.ReplaceAllString(rawLine, ""))
+		if len(tags) == 0 {
+			if m := lrcOffset.FindStringSubmatch(text); m != nil {
+				ms, _ := strconv.Atoi(m[1])
+				offset = lavalink.Duration(ms) * lavalink.Millisecond
+				continue
+			}
+			// [ar:가수] 같은 메타데이터 태그는 건너뜀
+			if strings.HasPrefix(text, "[") && strings.HasSuffix(text, "]") {
+				continue
+			}
+			plain = append(plain, text)
+			continue
+		}
+		plain = append(plain, text)
+		for _, tag := range tags {
+			minutes, _ := strconv.Atoi(tag[1])
+			seconds, _ := strconv.Atoi(tag[2])
+			start := lavalink.Duration(minutes)*lavalink.Minute + lavalink.Duration(seconds)*lavalink.Second
+			if tag[3] != "" {
+				// 소수점 자리수(1~3)에 맞춰 밀리초로 변환
+				frac, _ := strconv.Atoi(tag[3])
+				for i := len(tag[3]); i < 3; i++ {
+					frac *= 10
+				}
+				start += lavalink.Duration(frac) * lavalink.Millisecond
+			}
+			l.Lines = append(l.Lines, Line{Start: start, Text: text})
+		}
+	}
+	// offset 태그는 가사 중간에 있어도 전체에 적용
+	if offset != 0 {
+		for i := range l.Lines {
+			l.Lines[i].Start = max(l.Lines[i].Start-offset, 0)
+		}
+	}
+	sort.SliceStable(l.Lines, func(i, j int) bool { return l.Lines[i].Start < l.Lines[j].Start })
+	l.Text = strings.TrimSpace(strings.Join(plain, "\n"))
+	return l
+}
@@ -0,0 +1,156 @@
+package lyrics
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/disgoorg/disgolink/v3/lavalink"
+)
+
+func TestParseLRC(t *testing.T) {
+	tests := []struct {
+		name  string
+		raw   string
+		lines []Line
+		text  string
+	}{
+		{
+			name:  "분:초.백분의 일초",
+			raw:   "[00:01.50] 첫 줄\n[00:03.00] 둘째 줄",
+			lines: []Line{{Start: 1500, Text: "첫 줄"}, {Start: 3000, Text: "둘째 줄"}},
+			text:  "첫 줄\n둘째 줄",
+		},
+		{
+			name:  "분:초",
+			raw:   "[01:02] 한 줄",
+			lines: []Line{{Start: 62_000, Text: "한 줄"}},
+			text:  "한 줄",
+		},
+		{
+			name:  "밀리초와 콜론 구분자",
+			raw:   "[00:01.5] a\n[00:02:250] b\n[00:03.125] c",
+			lines: []Line{{Start: 1500, Text: "a"}, {Start: 2250, Text: "b"}, {Start: 3125, Text: "c"}},
+			text:  "a\nb\nc",
+		},
+		{
+			name:  "한 줄에 태그 여러 개",
+			raw:   "[00:10.00][00:01.00] 후렴\n[00:05.00] 절",
+			lines: []Line{{Start: 1000, Text: "후렴"}, {Start: 5000, Text: "절"}, {Start: 10_000, Text: "후렴"}},
+			text:  "후렴\n절",
+		},
+		{
+			name:  "메타데이터와 CRLF",
+			raw:   "[ar:가수]\r\n[ti:제목]\r\n[00:01.00] 가사\r\n",
+			lines: []Line{{Start: 1000, Text: "가사"}},
+			text:  "가사",
+		},
+		{
+			name:  "양수 offset은 앞당김",
+			raw:   "[offset:+500]\n[00:01.00] a\n[00:00.20] b",
+			lines: []Line{{Start: 0, Text: "b"}, {Start: 500, Text: "a"}},
+			text:  "a\nb",
+		},
+		{
+			name:  "음수 offset은 늦춤",
+			raw:   "[00:01.00] a\n[offset: -250]",
+			lines: []Line{{Start: 1250, Text: "a"}},
+			text:  "a",
+		},
+		{
+			name: "시간 태그 없는 가사",
+			raw:  "그냥 가사\n\n둘째 줄",
+			text: "그냥 가사\n\n둘째 줄",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := ParseLRC(tt.raw)
+			if !slices.Equal(l.Lines, tt.lines) {
+				t.Errorf("Lines = %v, want %v", l.Lines, tt.lines)
+			}
+			if l.Text != tt.text {
+				t.Errorf("Text = %q, want %q", l.Text, tt.text)
+			}
+			if l.Synced() != (len(tt.lines) > 0) {
+				t.Errorf("Synced = %v", l.Synced())
+			}
+		})
+	}
+}
+
+func TestCurrentLine(t *testing.T) {
+	l := &Lyrics{Lines: []Line{{Start: 1000}, {Start: 3000}, {Start: 3000}, {Start: 8000}}}
+	tests := []struct {
+		position lavalink.Duration
+		want     int
+	}{
+		{position: 0, want: -1},
+		{position: 999, want: -1},
+		{position: 1000, want: 0},
+		{position: 2999, want: 0},
+		// 같은 시각의 줄이 여럿이면 마지막 줄
+		{position: 3000, want: 2},
+		{position: 8000, want: 3},
+		{position: 60_000, want: 3},
+	}
+	for _, tt := range tests {
+		if got := l.CurrentLine(tt.position); got != tt.want {
+			t.Errorf("CurrentLine(%d) = %d, want %d", tt.position, got, tt.want)
+		}
+	}
+	if got := (&Lyrics{}).CurrentLine(1000); got != -1 {
+		t.Errorf("빈 가사 CurrentLine = %d, want -1", got)
+	}
+}
+
+func TestPages(t *testing.T) {
+	tests := []struct {
+		name     string
+		lyrics   Lyrics
+		maxChars int
+		want     []string
+	}{
+		{name: "빈 가사", lyrics: Lyrics{}, maxChars: 10, want: []string{""}},
+		{name: "한 페이지", lyrics: Lyrics{Text: "abc\ndef"}, maxChars: 7, want: []string{"abc\ndef"}},
+		{name: "한도를 넘으면 줄 단위로 나눔", lyrics: Lyrics{Text: "abc\ndef\ngh"}, maxChars: 6, want: []string{"abc", "def\ngh"}},
+		{name: "앞뒤 공백 제거", lyrics: Lyrics{Text: "\n\nabc\n"}, maxChars: 10, want: []string{"abc"}},
+		{name: "긴 줄은 자름", lyrics: Lyrics{Text: "abcdefgh\nij"}, maxChars: 5, want: []string{"abcde", "ij"}},
+		{name: "싱크 가사만 있음", lyrics: Lyrics{Lines: []Line{{Text: "a"}, {Text: "b"}}}, maxChars: 10, want: []string{"a\nb"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.lyrics.Pages(tt.maxChars)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Pages(%d) = %q, want %q", tt.maxChars, got, tt.want)
+			}
+		})
+	}
+}
+
+// 임베드 한도에 맞춰 나눌 때 모든 페이지가 한도 이하이고 한글이 깨지지 않아야 합니다.
+func TestPagesEmbedLimit(t *testing.T) {
+	const limit = 2000
+	lines := []string{strings.Repeat("가", 1000)} // 3000바이트짜리 한 줄
+	for i := range 300 {
+		lines = append(lines, strings.Repeat("라", i%20+1))
+	}
+	l := &Lyrics{Text: strings.Join(lines, "\n")}
+
+	pages := l.Pages(limit)
+	if len(pages) < 2 {
+		t.Fatalf("페이지 수 = %d, want 2 이상", len(pages))
+	}
+	for i, page := range pages {
+		if len(page) > limit {
+			t.Errorf("pages[%d] 길이 = %d, 한도 %d 초과", i, len(page), limit)
+		}
+		if !utf8.ValidString(page) {
+			t.Errorf("pages[%d]에 깨진 문자가 있습니다", i)
+		}
+	}
+	if got := strings.Join(pages[1:], "\n"); got != strings.Join(lines[1:], "\n") {
+		t.Error("나눈 페이지를 이으면 원래 가사와 같아야 합니다")
+	}
+}
@@ -0,0 +1,127 @@
+package metrics
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWriteGolden(t *testing.T) {
+	r := NewRegistry()
+	commands := r.Counter("test_commands_total", "실행한 명령어 수\n(역슬래시 \\ 포함)", "command")
+	up := r.Gauge("test_up", "연결 여부")
+	latency := r.Histogram("test_latency_seconds", "지연 시간", []float64{0.1, 0.5, 1}, "route")
+
+	commands.Inc("play")
+	commands.Add(2, `say "hi"`+"\n"+`C:\path`)
+	up.Set(1)
+	for _, v := range []float64{0.05, 0.1, 0.3, 0.7, 2} {
+		latency.Observe(v, "/a")
+	}
+
+	var buf bytes.Buffer
+	if err := r.Write(&buf); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	want := `# HELP test_commands_total 실행한 명령어 수\n(역슬래시 \\ 포함)
+# TYPE test_commands_total counter
+test_commands_total{command="play"} 1
+test_commands_total{command="say \"hi\"\nC:\\path"} 2
+# HELP test_up 연결 여부
+# TYPE test_up gauge
+test_up 1
+# HELP test_latency_seconds 지연 시간
+# TYPE test_latency_seconds histogram
+test_latency_seconds_bucket{route="/a",le="0.1"} 2
+test_latency_seconds_bucket{route="/a",le="0.5"} 3
+test_latency_seconds_bucket{route="/a",le="1"} 4
+test_latency_seconds_bucket{route="/a",le="+Inf"} 5
+test_latency_seconds_sum{route="/a"} 3.15
+test_latency_seconds_count{route="/a"} 5
+`
+	if got := buf.String(); got != want {
+		t.Errorf("Write 결과가 다릅니다\n--- got\n%s--- want\n%s", got, want)
+	}
+}
+
+func TestHistogramWithoutLabels(t *testing.T) {
+	r := NewRegistry()
+	h := r.Histogram("test_seconds", "h", []float64{1})
+	h.Observe(5)
+
+	var buf bytes.Buffer
+	_ = r.Write(&buf)
+	for _, line := range []string{
+		`test_seconds_bucket{le="1"} 0`,
+		`test_seconds_bucket{le="+Inf"} 1`,
+		`test_seconds_sum 5`,
+		`test_seconds_count 1`,
+	} {
+		if !strings.Contains(buf.String(), line+"\n") {
+			t.Errorf("%q가 없습니다:\n%s", line, buf.String())
+		}
+	}
+}
+
+func TestRegistryPanics(t *testing.T) {
+	tests := map[string]func(r *Registry){
+		"중복 이름":     func(r *Registry) { r.Gauge("dup", ""); r.Counter("dup", "") },
+		"정렬 안 된 구간": func(r *Registry) { r.Histogram("h", "", []float64{1, 0.5}) },
+		"레이블 수 불일치": func(r *Registry) { r.Gauge("g", "", "a").Set(1) },
+		"카운터 감소":    func(r *Registry) { r.Counter("c", "").Add(-1) },
+	}
+	for name, fn := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("panic이 발생하지 않았습니다")
+				}
+			}()
+			fn(NewRegistry())
+		})
+	}
+}
+
+// 동시에 들어온 스크랩이 다른 스크랩의 Reset 직후 값을 읽으면 시리즈가 빠진 채로 나가므로,
+// 수집과 쓰기는 한 번에 하나씩만 돌아야 합니다.
+func TestConcurrentWrite(t *testing.T) {
+	r := NewRegistry()
+	g := r.Gauge("test_nodes", "노드", "node")
+	var running, overlapped atomic.Int32
+	r.OnCollect(func() {
+		if running.Add(1) > 1 {
+			overlapped.Add(1)
+		}
+		defer running.Add(-1)
+		g.Reset()
+		// Reset과 Set 사이에 다른 스크랩이 끼어들 틈을 넓힘
+		time.Sleep(time.Millisecond)
+		for _, node := range []string{"a", "b", "c"} {
+			g.Set(1, node)
+		}
+	})
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 10 {
+				var buf bytes.Buffer
+				_ = r.Write(&buf)
+				if n := strings.Count(buf.String(), "test_nodes{"); n != 3 {
+					t.Errorf("시리즈 수 = %d, want 3", n)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if n := overlapped.Load(); n > 0 {
+		t.Errorf("수집이 %d번 겹쳐서 실행되었습니다", n)
+	}
+}
@@ -0,0 +1,82 @@
+package permission
+
+import (
+	"slices"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/uzih05/discord-music-bot/internal/command"
+	"github.com/uzih05/discord-music-bot/internal/settings"
+)
+
+// Restricted는 DJ 역할이 설정된 길드에서 기본적으로 DJ만 사용할 수 있는 명령어/버튼 ID입니다.
+// DJ 역할이 없으면 모두 사용할 수 있습니다.
+var Restricted = []string{
+	"pause", "skip", "previous", "seek", "forward", "rewind", "stop",
+	"move", "remove", "volume", "repeat", "shuffle", "queuemode", "autoplay", "filter", "eq",
+	"np_voldown", "np_volup", "np_skip", "np_repeat", "np_previous", "np_rewind", "np_forward",
+}
+
+// RequesterExempt는 곡을 신청한 본인이라면 권한과 관계없이 허용하는 동작입니다.
+var RequesterExempt = []string{"skip", "np_skip", "remove"}
+
+// Member는 권한 판단에 필요한 멤버 정보입니다.
+// RoleIDs에는 @everyone 역할(길드 ID)도 포함해야 합니다.
+type Member struct {
+	RoleIDs []snowflake.ID
+	Admin   bool
+}
+
+// Allowed는 길드 권한 매트릭스에 따라 동작 허용 여부를 판단합니다.
+// 길드별 설정(Permissions)이 있으면 기본값보다 우선합니다.
+func Allowed(g settings.Guild, action string, m Member) bool {
+	if m.Admin {
+		return true
+	}
+
+	if roles, ok := g.Permissions[action]; ok {
+		for _, roleID := range roles {
+			if slices.Contains(m.RoleIDs, roleID) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if g.DJRoleID == 0 || !slices.Contains(Restricted, action) {
+		return true
+	}
+	return slices.Contains(m.RoleIDs, g.DJRoleID)
+}
+
+// Exempt는 곡을 신청한 본인(requesterID)이 RequesterExempt 동작을 하는지 확인합니다.
+// 신청자가 없는 곡(자동 재생 등)은 예외가 아닙니다.
+func Exempt(action string, requesterID, userID snowflake.ID) bool {
+	return requesterID != 0 && requesterID == userID && slices.Contains(RequesterExempt, action)
+}
+
+// IsDJ는 DJ 역할이나 관리 권한을 가진 멤버인지 확인합니다.
+func IsDJ(g settings.Guild, m Member) bool {
+	if m.Admin {
+		return true
+	}
+	return g.DJRoleID != 0 && slices.Contains(m.RoleIDs, g.DJRoleID)
+}
+
+// Buttons는 권한을 지정할 수 있는 Now Playing 버튼 ID입니다.
+var Buttons = []string{
+	"np_voldown", "np_volup", "np_skip", "np_repeat", "np_queue", "np_previous", "np_rewind", "np_forward",
+}
+
+// Configurable은 권한을 따로 지정할 수 있는 명령어/버튼 ID인지 확인합니다.
+// 설정 명령어(/dj)는 잠금 방지를 위해, /api는 토큰 유출을 막기 위해 제외합니다.
+func Configurable(action string) bool {
+	if slices.Contains(Buttons, action) {
+		return true
+	}
+	for _, c := range command.Commands {
+		if name := c.CommandName(); name == action && name != "dj" && name != "api" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package permission
+
+import (
+	"testing"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/uzih05/discord-music-bot/internal/settings"
+)
+
+func TestAllowed(t *testing.T) {
+	const (
+		everyone snowflake.ID = 1
+		dj       snowflake.ID = 2
+		mod      snowflake.ID = 3
+	)
+	var (
+		admin    = Member{RoleIDs: []snowflake.ID{everyone}, Admin: true}
+		djMember = Member{RoleIDs: []snowflake.ID{everyone, dj}}
+		modUser  = Member{RoleIDs: []snowflake.ID{everyone, mod}}
+		plain    = Member{RoleIDs: []snowflake.ID{everyone}}
+	)
+	noDJ := settings.Guild{}
+	withDJ := settings.Guild{DJRoleID: dj}
+
+	tests := []struct {
+		name   string
+		guild  settings.Guild
+		action string
+		member Member
+		want   bool
+	}{
+		{name: "관리자는 항상 허용", guild: withDJ, action: "stop", member: admin, want: true},
+		{name: "관리자는 역할 지정보다 우선", guild: settings.Guild{Permissions: map[string][]snowflake.ID{"stop": {mod}}}, action: "stop", member: admin, want: true},
+
+		{name: "DJ 역할이 없으면 제한 명령어도 허용", guild: noDJ, action: "stop", member: plain, want: true},
+		{name: "DJ 역할이 없으면 버튼도 허용", guild: noDJ, action: "np_skip", member: plain, want: true},
+		{name: "DJ 역할이 있으면 제한 명령어는 DJ만", guild: withDJ, action: "stop", member: plain, want: false},
+		{name: "DJ는 제한 명령어 허용", guild: withDJ, action: "stop", member: djMember, want: true},
+		{name: "DJ 역할이 있어도 제한 없는 명령어는 허용", guild: withDJ, action: "play", member: plain, want: true},
+		{name: "DJ 역할이 있어도 대기열 버튼은 허용", guild: withDJ, action: "np_queue", member: plain, want: true},
+
+		{name: "역할 지정이 DJ 기본값보다 우선 (허용)", guild: settings.Guild{DJRoleID: dj, Permissions: map[string][]snowflake.ID{"stop": {mod}}}, action: "stop", member: modUser, want: true},
+		{name: "역할 지정이 DJ 기본값보다 우선 (DJ도 거부)", guild: settings.Guild{DJRoleID: dj, Permissions: map[string][]snowflake.ID{"stop": {mod}}}, action: "stop", member: djMember, want: false},
+		{name: "DJ 역할 없이 역할 지정만 있음", guild: settings.Guild{Permissions: map[string][]snowflake.ID{"volume": {mod}}}, action: "volume", member: plain, want: false},
+		{name: "제한 없는 명령어도 역할 지정을 따름", guild: settings.Guild{Permissions: map[string][]snowflake.ID{"play": {mod}}}, action: "play", member: plain, want: false},
+		{name: "@everyone 지정은 모두 허용", guild: settings.Guild{DJRoleID: dj, Permissions: map[string][]snowflake.ID{"stop": {everyone}}}, action: "stop", member: plain, want: true},
+		{name: "빈 역할 목록은 관리자만", guild: settings.Guild{Permissions: map[string][]snowflake.ID{"stop": {}}}, action: "stop", member: djMember, want: false},
+		{name: "다른 동작의 지정은 영향 없음", guild: settings.Guild{DJRoleID: dj, Permissions: map[string][]snowflake.ID{"volume": {mod}}}, action: "stop", member: modUser, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Allowed(tt.guild, tt.action, tt.member); got != tt.want {
+				t.Errorf("Allowed(%q) = %v, want %v", tt.action, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExempt(t *testing.T) {
+	const user snowflake.ID = 10
+
+	tests := []struct {
+		name      string
+		action    string
+		requester snowflake.ID
+		want      bool
+	}{
+		{name: "본인 곡 스킵", action: "skip", requester: user, want: true},
+		{name: "본인 곡 스킵 버튼", action: "np_skip", requester: user, want: true},
+		{name: "본인 곡 삭제", action: "remove", requester: user, want: true},
+		{name: "다른 사람 곡", action: "skip", requester: 11, want: false},
+		{name: "신청자 없는 곡", action: "skip", requester: 0, want: false},
+		{name: "예외가 아닌 동작", action: "stop", requester: user, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Exempt(tt.action, tt.requester, user); got != tt.want {
+				t.Errorf("Exempt(%q, %d) = %v, want %v", tt.action, tt.requester, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsDJ(t *testing.T) {
+	const dj snowflake.ID = 2
+
+	tests := []struct {
+		name   string
+		guild  settings.Guild
+		member Member
+		want   bool
+	}{
+		{name: "관리자", guild: settings.Guild{}, member: Member{Admin: true}, want: true},
+		{name: "DJ 역할 보유", guild: settings.Guild{DJRoleID: dj}, member: Member{RoleIDs: []snowflake.ID{dj}}, want: true},
+		{name: "DJ 역할 없음", guild: settings.Guild{DJRoleID: dj}, member: Member{RoleIDs: []snowflake.ID{3}}, want: false},
+		{name: "DJ 역할 미설정", guild: settings.Guild{}, member: Member{RoleIDs: []snowflake.ID{0}}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsDJ(tt.guild, tt.member); got != tt.want {
+				t.Errorf("IsDJ = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigurable(t *testing.T) {
+	tests := map[string]bool{
+		"play":     true,
+		"stop":     true,
+		"np_skip":  true,
+		"np_queue": true,
+		"dj":       false,
+		"api":      false,
+		"unknown":  false,
+	}
+	for action, want := range tests {
+		if got := Configurable(action); got != want {
+			t.Errorf("Configurable(%q) = %v, want %v", action, got, want)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package player
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/disgoorg/disgolink/v3/lavalink"
+)
+
+const (
+	EqBands   = 15
+	EqMinGain = -0.25
+	EqMaxGain = 1.0
+)
+
+var (
+	ErrUnknownPreset = errors.New("알 수 없는 필터입니다")
+	ErrInvalidBand   = errors.New("밴드는 0-14 사이여야 합니다")
+	ErrInvalidGain   = errors.New("게인은 -0.25 ~ 1.0 사이여야 합니다")
+)
+
+// FilterPreset은 /filter로 켜고 끌 수 있는 이름 있는 필터입니다.
+// 같은 Group의 프리셋은 동시에 켤 수 없으며, 새로 켠 쪽이 기존 것을 대체합니다.
+type FilterPreset struct {
+	Name  string
+	Label string
+	Group string
+	apply func(f *lavalink.Filters)
+}
+
+// FilterPresets는 등록 순서대로 적용됩니다.
+var FilterPresets = []FilterPreset{
+	{Name: "bassboost", Label: "베이스 부스트", Group: "equalizer", apply: func(f *lavalink.Filters) {
+		addEqualizer(f, lavalink.Equalizer{0.2, 0.15, 0.1, 0.05, 0.0, -0.05})
+	}},
+	{Name: "nightcore", Label: "나이트코어", Group: "timescale", apply: func(f *lavalink.Filters) {
+		f.Timescale = &lavalink.Timescale{Speed: 1.2, Pitch: 1.2, Rate: 1.0}
+	}},
+	{Name: "vaporwave", Label: "베이퍼웨이브", Group: "timescale", apply: func(f *lavalink.Filters) {
+		f.Timescale = &lavalink.Timescale{Speed: 0.85, Pitch: 0.8, Rate: 1.0}
+	}},
+	// disgolink의 Rotation은 정수 Hz만 받으므로 가장 느린 1Hz를 사용
+	{Name: "8d", Label: "8D", Group: "rotation", apply: func(f *lavalink.Filters) {
+		f.Rotation = &lavalink.Rotation{RotationHz: 1}
+	}},
+	{Name: "karaoke", Label: "노래방 (보컬 제거)", Group: "karaoke", apply: func(f *lavalink.Filters) {
+		f.Karaoke = &lavalink.Karaoke{Level: 1.0, MonoLevel: 1.0, FilterBand: 220.0, FilterWidth: 100.0}
+	}},
+	{Name: "tremolo", Label: "트레몰로", Group: "tremolo", apply: func(f *lavalink.Filters) {
+		f.Tremolo = &lavalink.Tremolo{Frequency: 4.0, Depth: 0.75}
+	}},
+	{Name: "lowpass", Label: "로우패스 (부드럽게)", Group: "lowpass", apply: func(f *lavalink.Filters) {
+		f.LowPass = &lavalink.LowPass{Smoothing: 20.0}
+	}},
+}
+
+func FindPreset(name string) (FilterPreset, bool) {
+	i := slices.IndexFunc(FilterPresets, func(p FilterPreset) bool { return p.Name == name })
+	if i < 0 {
+		return FilterPreset{}, false
+	}
+	return FilterPresets[i], true
+}
+
+// FilterState는 길드에 적용 중인 필터 설정입니다.
+// 곡이 바뀌거나 Lavalink 플레이어가 새로 만들어져도 이 값으로 다시 적용합니다.
+type FilterState struct {
+	Presets   []string            `json:"presets,omitempty"`
+	Equalizer *lavalink.Equalizer `json:"equalizer,omitempty"`
+}
+
+func (s FilterState) Active() bool {
+	return len(s.Presets) > 0 || s.Equalizer != nil
+}
+
+// Labels는 켜진 필터의 표시 이름입니다. 사용자 EQ는 마지막에 붙습니다.
+func (s FilterState) Labels() []string {
+	var labels []string
+	for _, name := range s.Presets {
+		if p, ok := FindPreset(name); ok {
+			labels = append(labels, p.Label)
+		}
+	}
+	if s.Equalizer != nil {
+		labels = append(labels, "사용자 EQ")
+	}
+	return labels
+}
+
+// Build는 Lavalink에 보낼 필터를 만듭니다. 프리셋 EQ와 사용자 EQ는 밴드별로 더합니다.
+func (s FilterState) Build() lavalink.Filters {
+	var f lavalink.Filters
+	for _, preset := range FilterPresets {
+		if slices.Contains(s.Presets, preset.Name) {
+			preset.apply(&f)
+		}
+	}
+	if s.Equalizer != nil {
+		addEqualizer(&f, *s.Equalizer)
+	}
+	return f
+}
+
+func addEqualizer(f *lavalink.Filters, eq lavalink.Equalizer) {
+	if f.Equalizer == nil {
+		f.Equalizer = &lavalink.Equalizer{}
+	}
+	for band, gain := range eq {
+		f.Equalizer[band] = min(max(f.Equalizer[band]+gain, EqMinGain), EqMaxGain)
+	}
+}
+
+func (s FilterState) clone() FilterState {
+	s.Presets = slices.Clone(s.Presets)
+	if s.Equalizer != nil {
+		eq := *s.Equalizer
+		s.Equalizer = &eq
+	}
+	return s
+}
+
+func (gp *GuildPlayer) FilterState() FilterState {
+	gp.Mu.Lock()
+	defer gp.Mu.Unlock()
+	return gp.Filters.clone()
+}
+
+// TogglePreset은 프리셋을 켜거나 끄고 켜졌는지 여부를 반환합니다.
+func (gp *GuildPlayer) TogglePreset(name string) (bool, error) {
+	preset, ok := FindPreset(name)
+	if !ok {
+		return false, ErrUnknownPreset
+	}
+
+	defer gp.changed()
+	gp.Mu.Lock()
+	defer gp.Mu.Unlock()
+
+	if i := slices.Index(gp.Filters.Presets, name); i >= 0 {
+		gp.Filters.Presets = slices.Delete(gp.Filters.Presets, i, i+1)
+		return false, nil
+	}
+	gp.Filters.Presets = slices.DeleteFunc(gp.Filters.Presets, func(other string) bool {
+		p, ok := FindPreset(other)
+		return ok && p.Group == preset.Group
+	})
+	gp.Filters.Presets = append(gp.Filters.Presets, name)
+	return true, nil
+}
+
+// SetEqBand는 사용자 EQ의 한 밴드 게인을 설정합니다.
+func (gp *GuildPlayer) SetEqBand(band int, gain float32) error {
+	if band < 0 || band >= EqBands {
+		return ErrInvalidBand
+	}
+	if gain < EqMinGain || gain > EqMaxGain {
+		return ErrInvalidGain
+	}
+
+	defer gp.changed()
+	gp.Mu.Lock()
+	defer gp.Mu.Unlock()
+
+	if gp.Filters.Equalizer == nil {
+		gp.Filters.Equalizer = &lavalink.Equalizer{}
+	}
+	gp.Filters.Equalizer[band] = gain
+	if *gp.Filters.Equalizer == (lavalink.Equalizer{}) {
+		gp.Filters.Equalizer = nil
+	}
+	return nil
+}
+
+func (gp *GuildPlayer) ResetEqualizer() {
+	defer gp.changed()
+	gp.Mu.Lock()
+	defer gp.Mu.Unlock()
+	gp.Filters.Equalizer = nil
+}
+
+// ClearFilters는 프리셋과 사용자 EQ를 모두 끕니다.
+func (gp *GuildPlayer) ClearFilters() {
+	defer gp.changed()
+	gp.Mu.Lock()
+	defer gp.Mu.Unlock()
+	gp.Filters = FilterState{}
+}
@@ -2,6 +2,7 @@ package player
 
 import (
 	"math/rand/v2"
+	"slices"
 	"sync"
 	"time"
 
@@ -9,6 +10,9 @@ import (
 	"github.com/disgoorg/snowflake/v2"
 )
 
+// MaxHistory는 길드별로 보관하는 재생 기록의 최대 개수입니다.
+const MaxHistory = 50
+
 type RepeatMode int
 
 const (
@@ -28,36 +32,74 @@ func (r RepeatMode) String() string {
 	}
 }
 
+type QueueMode int
+
+const (
+	QueueFIFO QueueMode = iota
+	// QueueFair는 신청자별로 한 곡씩 번갈아 재생합니다.
+	QueueFair
+)
+
+func (q QueueMode) String() string {
+	if q == QueueFair {
+		return "공평 분배"
+	}
+	return "순서대로"
+}
+
 type GuildPlayer struct {
-	GuildID              snowflake.ID
-	TextChannelID        snowflake.ID
-	Queue                []lavalink.Track
-	NowPlayingMessageID  snowflake.ID
-	NowPlayingChannelID  snowflake.ID
-	Repeat               RepeatMode
-	CurrentTrack         *lavalink.Track
-	Volume               int
-	StopUpdateCh         chan struct{}
-	IdleTimer            *time.Timer
-	IdleMessageID        snowflake.ID
-	IdleChannelID        snowflake.ID
-	Mu                   sync.Mutex
-}
-
-func NewGuildPlayer(guildID snowflake.ID) *GuildPlayer {
+	GuildID             snowflake.ID
+	TextChannelID       snowflake.ID
+	Queue               []lavalink.Track
+	NowPlayingMessageID snowflake.ID
+	NowPlayingChannelID snowflake.ID
+	Repeat              RepeatMode
+	QueueMode           QueueMode
+	CurrentTrack        *lavalink.Track
+	History             []lavalink.Track
+	Volume              int
+	Filters             FilterState
+	StopUpdateCh        chan struct{}
+	IdleTimer           *time.Timer
+	IdleMessageID       snowflake.ID
+	IdleChannelID       snowflake.ID
+	SkipVotes           map[snowflake.ID]struct{}
+	SkipVotesNeeded     int
+	Mu                  sync.Mutex
+
+	// OnChange는 대기열/재생 상태가 바뀐 뒤 잠금 해제 상태에서 호출됩니다 (영속화용)
+	OnChange func(gp *GuildPlayer)
+}
+
+// NewGuildPlayer는 volume(길드 기본 볼륨)으로 시작하는 플레이어를 만듭니다.
+func NewGuildPlayer(guildID snowflake.ID, volume int) *GuildPlayer {
 	return &GuildPlayer{
 		GuildID: guildID,
-		Volume:  50,
+		Volume:  volume,
+	}
+}
+
+func (gp *GuildPlayer) changed() {
+	if gp.OnChange != nil {
+		gp.OnChange(gp)
 	}
 }
 
 func (gp *GuildPlayer) Add(tracks ...lavalink.Track) {
+	defer gp.changed()
 	gp.Mu.Lock()
 	defer gp.Mu.Unlock()
-	gp.Queue = append(gp.Queue, tracks...)
+	if gp.QueueMode != QueueFair {
+		gp.Queue = append(gp.Queue, tracks...)
+		return
+	}
+	for _, track := range tracks {
+		gp.Queue = fairInsert(gp.Queue, track, gp.currentRequester())
+	}
 }
 
 func (gp *GuildPlayer) Next() *lavalink.Track {
+	defer gp.changed()
 	gp.Mu.Lock()
 	defer gp.Mu.Unlock()
 
@@ -65,8 +107,16 @@ func (gp *GuildPlayer) Next() *lavalink.Track {
 		return gp.CurrentTrack
 	}
 
+	if gp.CurrentTrack != nil {
+		gp.pushHistory(*gp.CurrentTrack)
+	}
+
 	if gp.Repeat == RepeatAll && gp.CurrentTrack != nil {
-		gp.Queue = append(gp.Queue, *gp.CurrentTrack)
+		if gp.QueueMode == QueueFair {
+			gp.Queue = fairInsert(gp.Queue, *gp.CurrentTrack, gp.currentRequester())
+		} else {
+			gp.Queue = append(gp.Queue, *gp.CurrentTrack)
+		}
 	}
 
 	if len(gp.Queue) == 0 {
@@ -80,13 +130,62 @@ func (gp *GuildPlayer) Next() *lavalink.Track {
 	return &next
 }
 
+// pushHistory는 gp.Mu를 잡은 상태에서 호출해야 합니다.
+func (gp *GuildPlayer) pushHistory(track lavalink.Track) {
+	gp.History = append(gp.History, track)
+	if len(gp.History) > MaxHistory {
+		gp.History = gp.History[len(gp.History)-MaxHistory:]
+	}
+}
+
+// Previous는 가장 최근 재생 기록을 현재 곡으로 되돌리고,
+// 재생 중이던 곡은 대기열 맨 앞으로 보냅니다.
+func (gp *GuildPlayer) Previous() *lavalink.Track {
+	defer gp.changed()
+	gp.Mu.Lock()
+	defer gp.Mu.Unlock()
+
+	if len(gp.History) == 0 {
+		return nil
+	}
+
+	prev := gp.History[len(gp.History)-1]
+	gp.History = gp.History[:len(gp.History)-1]
+
+	if gp.CurrentTrack != nil {
+		gp.Queue = append([]lavalink.Track{*gp.CurrentTrack}, gp.Queue...)
+	}
+	gp.CurrentTrack = &prev
+	return &prev
+}
+
+// HistoryList는 재생 기록을 최근 곡부터 반환합니다.
+func (gp *GuildPlayer) HistoryList() []lavalink.Track {
+	gp.Mu.Lock()
+	defer gp.Mu.Unlock()
+
+	result := make([]lavalink.Track, len(gp.History))
+	for i, track := range gp.History {
+		result[len(gp.History)-1-i] = track
+	}
+	return result
+}
+
+func (gp *GuildPlayer) HistoryLen() int {
+	gp.Mu.Lock()
+	defer gp.Mu.Unlock()
+	return len(gp.History)
+}
+
 func (gp *GuildPlayer) SetCurrentTrack(track *lavalink.Track) {
+	defer gp.changed()
 	gp.Mu.Lock()
 	defer gp.Mu.Unlock()
 	gp.CurrentTrack = track
 }
 
 func (gp *GuildPlayer) Shuffle() {
+	defer gp.changed()
 	gp.Mu.Lock()
 	defer gp.Mu.Unlock()
 
@@ -94,6 +193,97 @@ func (gp *GuildPlayer) Shuffle() {
 		j := rand.IntN(i + 1)
 		gp.Queue[i], gp.Queue[j] = gp.Queue[j], gp.Queue[i]
 	}
+	gp.rebalance()
+}
+
+// SetQueueMode는 대기열 모드를 바꾸고 현재 대기열을 새 모드 순서로 정렬합니다.
+func (gp *GuildPlayer) SetQueueMode(mode QueueMode) {
+	defer gp.changed()
+	gp.Mu.Lock()
+	defer gp.Mu.Unlock()
+	gp.QueueMode = mode
+	gp.rebalance()
+}
+
+// rebalance는 공평 분배 모드일 때 대기열 전체를 신청자별 라운드 로빈 순서로 재배치합니다.
+// 모드를 바꾸거나 섞을 때만 쓰며, 곡 추가는 fairInsert로 이동/삭제한 순서를 유지합니다.
+// gp.Mu를 잡은 상태에서 호출해야 합니다.
+func (gp *GuildPlayer) rebalance() {
+	if gp.QueueMode != QueueFair {
+		return
+	}
+	gp.Queue = fairOrder(gp.Queue, gp.currentRequester())
+}
+
+// currentRequester는 gp.Mu를 잡은 상태에서 호출해야 합니다.
+func (gp *GuildPlayer) currentRequester() snowflake.ID {
+	if gp.CurrentTrack == nil {
+		return 0
+	}
+	return Requester(*gp.CurrentTrack)
+}
+
+// fairInsert는 기존 대기열 순서는 그대로 두고 새 곡 하나를 신청자 차례에 맞는 자리에 끼워 넣습니다.
+// 각 곡의 차례(round)는 앞에 있는 같은 신청자의 곡 수이며, last(현재 곡 신청자)는 한 차례 늦게 셉니다.
+// 새 곡은 자기 차례가 끝나는 자리(더 늦은 차례의 첫 곡 앞)에 들어가되, 같은 신청자의 곡보다 앞서지 않습니다.
+func fairInsert(queue []lavalink.Track, track lavalink.Track, last snowflake.ID) []lavalink.Track {
+	start := func(user snowflake.ID) int {
+		if user == last {
+			return 1
+		}
+		return 0
+	}
+
+	counts := make(map[snowflake.ID]int)
+	rounds := make([]int, len(queue))
+	user := Requester(track)
+	pos := len(queue)
+	after := 0
+	for i, t := range queue {
+		u := Requester(t)
+		rounds[i] = counts[u] + start(u)
+		counts[u]++
+		if u == user {
+			after = i + 1
+		}
+	}
+	round := counts[user] + start(user)
+	for i := after; i < len(queue); i++ {
+		if rounds[i] > round {
+			pos = i
+			break
+		}
+	}
+	return slices.Insert(queue, pos, track)
+}
+
+// fairOrder는 신청자별 곡 순서를 유지하면서 한 명씩 번갈아 가며 배치합니다.
+// 신청자 차례는 대기열에 처음 등장한 순서를 따르며, last(현재 곡 신청자)는 맨 뒤로 밀립니다.
+func fairOrder(queue []lavalink.Track, last snowflake.ID) []lavalink.Track {
+	var users []snowflake.ID
+	byUser := make(map[snowflake.ID][]lavalink.Track)
+	for _, track := range queue {
+		user := Requester(track)
+		if _, ok := byUser[user]; !ok {
+			users = append(users, user)
+		}
+		byUser[user] = append(byUser[user], track)
+	}
+
+	if i := slices.Index(users, last); i >= 0 {
+		users = slices.Concat(users[i+1:], users[:i+1])
+	}
+
+	result := make([]lavalink.Track, 0, len(queue))
+	for len(result) < len(queue) {
+		for _, user := range users {
+			if tracks := byUser[user]; len(tracks) > 0 {
+				result = append(result, tracks[0])
+				byUser[user] = tracks[1:]
+			}
+		}
+	}
+	return result
 }
 
 func (gp *GuildPlayer) StopUpdateLoop() {
@@ -115,6 +305,7 @@ func (gp *GuildPlayer) CancelIdleTimer() {
 }
 
 func (gp *GuildPlayer) Clear() {
+	defer gp.changed()
 	if gp.StopUpdateCh != nil {
 		close(gp.StopUpdateCh)
 		gp.StopUpdateCh = nil
@@ -127,14 +318,29 @@ func (gp *GuildPlayer) Clear() {
 	}
 	gp.Queue = nil
 	gp.CurrentTrack = nil
+	gp.History = nil
+	gp.SkipVotes = nil
+	gp.SkipVotesNeeded = 0
 	gp.Repeat = RepeatOff
+	gp.Filters = FilterState{}
 	gp.NowPlayingMessageID = 0
 	gp.NowPlayingChannelID = 0
 	gp.IdleMessageID = 0
 	gp.IdleChannelID = 0
 }
 
+// ClearQueue는 재생 중인 곡은 그대로 두고 대기열만 비운 뒤 비운 곡 수를 반환합니다.
+func (gp *GuildPlayer) ClearQueue() int {
+	defer gp.changed()
+	gp.Mu.Lock()
+	defer gp.Mu.Unlock()
+	n := len(gp.Queue)
+	gp.Queue = nil
+	return n
+}
+
 func (gp *GuildPlayer) NextRepeat() RepeatMode {
+	defer gp.changed()
 	gp.Mu.Lock()
 	defer gp.Mu.Unlock()
 	switch gp.Repeat {
@@ -148,6 +354,90 @@ func (gp *GuildPlayer) NextRepeat() RepeatMode {
 	return gp.Repeat
 }
 
+// RemoveRequester는 userID가 신청한 곡을 대기열에서 모두 삭제하고 삭제한 곡을 반환합니다.
+func (gp *GuildPlayer) RemoveRequester(userID snowflake.ID) []lavalink.Track {
+	defer gp.changed()
+	gp.Mu.Lock()
+	defer gp.Mu.Unlock()
+
+	var removed []lavalink.Track
+	kept := gp.Queue[:0]
+	for _, track := range gp.Queue {
+		if Requester(track) == userID {
+			removed = append(removed, track)
+			continue
+		}
+		kept = append(kept, track)
+	}
+	gp.Queue = kept
+	return removed
+}
+
+func (gp *GuildPlayer) SetVolume(volume int) {
+	defer gp.changed()
+	gp.Mu.Lock()
+	defer gp.Mu.Unlock()
+	gp.Volume = volume
+}
+
+// AdjustVolume은 볼륨을 delta만큼 0-100 범위 안에서 조절하고 새 값을 반환합니다.
+func (gp *GuildPlayer) AdjustVolume(delta int) int {
+	defer gp.changed()
+	gp.Mu.Lock()
+	defer gp.Mu.Unlock()
+	gp.Volume = min(max(gp.Volume+delta, 0), 100)
+	return gp.Volume
+}
+
+func (gp *GuildPlayer) SetRepeat(mode RepeatMode) {
+	defer gp.changed()
+	gp.Mu.Lock()
+	defer gp.Mu.Unlock()
+	gp.Repeat = mode
+}
+
+// AddSkipVote는 스킵 투표를 기록하고 현재 득표 수를 반환합니다.
+// listeners에 없는 사용자(채널을 떠난 사용자)의 표는 세지 않고 지웁니다.
+func (gp *GuildPlayer) AddSkipVote(userID snowflake.ID, listeners []snowflake.ID, needed int) int {
+	gp.Mu.Lock()
+	defer gp.Mu.Unlock()
+	if gp.SkipVotes == nil {
+		gp.SkipVotes = make(map[snowflake.ID]struct{})
+	}
+	gp.SkipVotes[userID] = struct{}{}
+	gp.pruneSkipVotes(listeners)
+	gp.SkipVotesNeeded = needed
+	return len(gp.SkipVotes)
+}
+
+// PruneSkipVotes는 listeners에 없는 사용자의 표를 지우고 필요한 표 수를 다시 정합니다.
+// 진행 중인 투표가 없으면 아무것도 하지 않고 false를 반환합니다.
+func (gp *GuildPlayer) PruneSkipVotes(listeners []snowflake.ID, needed int) bool {
+	gp.Mu.Lock()
+	defer gp.Mu.Unlock()
+	if len(gp.SkipVotes) == 0 {
+		return false
+	}
+	gp.pruneSkipVotes(listeners)
+	gp.SkipVotesNeeded = needed
+	return true
+}
+
+func (gp *GuildPlayer) pruneSkipVotes(listeners []snowflake.ID) {
+	for userID := range gp.SkipVotes {
+		if !slices.Contains(listeners, userID) {
+			delete(gp.SkipVotes, userID)
+		}
+	}
+}
+
+func (gp *GuildPlayer) ResetSkipVotes() {
+	gp.Mu.Lock()
+	defer gp.Mu.Unlock()
+	gp.SkipVotes = nil
+	gp.SkipVotesNeeded = 0
+}
+
 func (gp *GuildPlayer) QueueLen() int {
 	gp.Mu.Lock()
 	defer gp.Mu.Unlock()
@@ -167,7 +457,18 @@ func (gp *GuildPlayer) QueueList(max int) []lavalink.Track {
 	return result
 }
 
+// At은 대기열의 pos번째(1부터) 곡을 반환합니다.
+func (gp *GuildPlayer) At(pos int) (lavalink.Track, bool) {
+	gp.Mu.Lock()
+	defer gp.Mu.Unlock()
+	if pos < 1 || pos > len(gp.Queue) {
+		return lavalink.Track{}, false
+	}
+	return gp.Queue[pos-1], true
+}
+
 func (gp *GuildPlayer) Move(from, to int) (lavalink.Track, bool) {
+	defer gp.changed()
 	gp.Mu.Lock()
 	defer gp.Mu.Unlock()
 
@@ -191,6 +492,7 @@ func (gp *GuildPlayer) Move(from, to int) (lavalink.Track, bool) {
 }
 
 func (gp *GuildPlayer) Remove(pos int) (lavalink.Track, bool) {
+	defer gp.changed()
 	gp.Mu.Lock()
 	defer gp.Mu.Unlock()
 
@@ -0,0 +1,136 @@
+package player
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/disgoorg/disgolink/v3/lavalink"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// queueOf는 "A1 B1 A2"처럼 신청자(첫 글자)와 번호로 된 곡 목록을 만듭니다.
+func queueOf(names ...string) []lavalink.Track {
+	tracks := make([]lavalink.Track, 0, len(names))
+	for _, name := range names {
+		tracks = append(tracks, WithRequester(lavalink.Track{Encoded: name}, snowflake.ID(name[0])))
+	}
+	return tracks
+}
+
+func namesOf(tracks []lavalink.Track) []string {
+	names := make([]string, 0, len(tracks))
+	for _, track := range tracks {
+		names = append(names, track.Encoded)
+	}
+	return names
+}
+
+func TestFairOrder(t *testing.T) {
+	tests := []struct {
+		name  string
+		queue []string
+		last  byte
+		want  []string
+	}{
+		{name: "빈 대기열", queue: nil, want: []string{}},
+		{name: "신청자 한 명", queue: []string{"A1", "A2", "A3"}, want: []string{"A1", "A2", "A3"}},
+		{name: "두 명 번갈아", queue: []string{"A1", "A2", "A3", "B1", "B2"}, want: []string{"A1", "B1", "A2", "B2", "A3"}},
+		{name: "세 명 번갈아", queue: []string{"A1", "A2", "B1", "C1", "C2", "B2"}, want: []string{"A1", "B1", "C1", "A2", "B2", "C2"}},
+		{name: "신청자별 순서 유지", queue: []string{"B2", "A3", "B1", "A1"}, want: []string{"B2", "A3", "B1", "A1"}},
+		{name: "현재 곡 신청자는 맨 뒤로", queue: []string{"A1", "A2", "B1", "C1"}, last: 'A', want: []string{"B1", "C1", "A1", "A2"}},
+		{name: "현재 곡 신청자가 대기열에 없음", queue: []string{"A1", "B1"}, last: 'C', want: []string{"A1", "B1"}},
+		{name: "신청자 한 명이 현재 곡 신청자", queue: []string{"A1", "A2"}, last: 'A', want: []string{"A1", "A2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := namesOf(fairOrder(queueOf(tt.queue...), snowflake.ID(tt.last)))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("fairOrder(%v, %c) = %v, want %v", tt.queue, tt.last, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFairInsert(t *testing.T) {
+	tests := []struct {
+		name  string
+		queue []string
+		track string
+		last  byte
+		want  []string
+	}{
+		{name: "빈 대기열", queue: nil, track: "A1", want: []string{"A1"}},
+		{name: "같은 신청자는 뒤에", queue: []string{"A1", "A2"}, track: "A3", want: []string{"A1", "A2", "A3"}},
+		{name: "새 신청자는 첫 차례 끝에", queue: []string{"A1", "B1", "A2", "B2"}, track: "C1", want: []string{"A1", "B1", "C1", "A2", "B2"}},
+		{name: "두 번째 차례에 끼움", queue: []string{"A1", "B1", "A2", "A3"}, track: "B2", want: []string{"A1", "B1", "A2", "B2", "A3"}},
+		{name: "현재 곡 신청자는 한 차례 늦게", queue: []string{"B1", "A1", "B2", "A2"}, track: "A3", last: 'A', want: []string{"B1", "A1", "B2", "A2", "A3"}},
+		{name: "다른 신청자는 현재 곡 신청자보다 먼저", queue: []string{"A1", "A2"}, track: "B1", last: 'A', want: []string{"B1", "A1", "A2"}},
+		// 직접 옮긴 순서(B1을 맨 앞으로)는 다시 정렬하지 않음
+		{name: "옮긴 순서 유지", queue: []string{"B1", "A1", "A2", "A3"}, track: "C1", want: []string{"B1", "A1", "C1", "A2", "A3"}},
+		{name: "같은 신청자의 곡보다 앞서지 않음", queue: []string{"A2", "A3", "B1", "A1"}, track: "A4", want: []string{"A2", "A3", "B1", "A1", "A4"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := namesOf(fairInsert(queueOf(tt.queue...), queueOf(tt.track)[0], snowflake.ID(tt.last)))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("fairInsert(%v, %s) = %v, want %v", tt.queue, tt.track, got, tt.want)
+			}
+		})
+	}
+}
+
+// 공평 분배 모드에서 곡을 추가하거나 넘겨도 직접 옮기거나 삭제한 순서가 되돌아가지 않아야 합니다.
+func TestFairQueueKeepsManualOrder(t *testing.T) {
+	gp := NewGuildPlayer(1, 100)
+	gp.SetQueueMode(QueueFair)
+	gp.Add(queueOf("A1", "A2", "A3", "B1", "B2")...)
+	if got, want := namesOf(gp.Queue), []string{"A1", "B1", "A2", "B2", "A3"}; !slices.Equal(got, want) {
+		t.Fatalf("Add 후 대기열 = %v, want %v", got, want)
+	}
+
+	gp.Move(5, 1)
+	gp.Remove(3)
+	gp.Add(queueOf("C1")...)
+	if got, want := namesOf(gp.Queue), []string{"A3", "C1", "A1", "A2", "B2"}; !slices.Equal(got, want) {
+		t.Fatalf("Move/Remove/Add 후 대기열 = %v, want %v", got, want)
+	}
+
+	gp.Next()
+	if got, want := namesOf(gp.Queue), []string{"C1", "A1", "A2", "B2"}; !slices.Equal(got, want) {
+		t.Errorf("Next 후 대기열 = %v, want %v", got, want)
+	}
+}
+
+func TestSkipVotes(t *testing.T) {
+	gp := NewGuildPlayer(1, 100)
+
+	if got := gp.AddSkipVote(10, []snowflake.ID{10, 11, 12}, 2); got != 1 {
+		t.Fatalf("AddSkipVote = %d, want 1", got)
+	}
+	if got := gp.AddSkipVote(11, []snowflake.ID{10, 11, 12}, 2); got != 2 {
+		t.Fatalf("AddSkipVote = %d, want 2", got)
+	}
+
+	// 10이 채널을 떠나면 그 표는 세지 않음
+	if got := gp.AddSkipVote(12, []snowflake.ID{11, 12, 13}, 2); got != 2 {
+		t.Errorf("떠난 사용자를 뺀 득표 = %d, want 2", got)
+	}
+	if _, ok := gp.SkipVotes[10]; ok {
+		t.Error("떠난 사용자의 표가 남아 있습니다")
+	}
+
+	if !gp.PruneSkipVotes([]snowflake.ID{12}, 1) {
+		t.Fatal("진행 중인 투표가 있는데 PruneSkipVotes = false")
+	}
+	if len(gp.SkipVotes) != 1 || gp.SkipVotesNeeded != 1 {
+		t.Errorf("정리 후 투표 = %d/%d, want 1/1", len(gp.SkipVotes), gp.SkipVotesNeeded)
+	}
+
+	gp.ResetSkipVotes()
+	if gp.PruneSkipVotes(nil, 1) {
+		t.Error("투표가 없는데 PruneSkipVotes = true")
+	}
+	if gp.SkipVotesNeeded != 0 {
+		t.Errorf("투표가 없을 때 SkipVotesNeeded = %d, want 0", gp.SkipVotesNeeded)
+	}
+}
@@ -0,0 +1,120 @@
+package player
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/disgoorg/disgolink/v3/lavalink"
+)
+
+var (
+	ErrInvalidTime   = errors.New("시간 형식이 올바르지 않습니다 (예: 1:23, 1:02:03, 90s, +30, -15)")
+	ErrNotSeekable   = errors.New("이 곡은 탐색할 수 없습니다")
+	ErrSeekOutOfSpan = errors.New("곡 길이를 벗어난 위치입니다")
+)
+
+// ParseTime은 "1:23", "1:02:03", "90", "90s", "1m30s", "1h2m3s" 형식을 해석합니다.
+func ParseTime(s string) (lavalink.Duration, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	if s == "" {
+		return 0, ErrInvalidTime
+	}
+
+	if strings.Contains(s, ":") {
+		parts := strings.Split(s, ":")
+		if len(parts) > 3 {
+			return 0, ErrInvalidTime
+		}
+		var total lavalink.Duration
+		for i, part := range parts {
+			n, err := strconv.Atoi(part)
+			if err != nil || n < 0 || (i > 0 && n >= 60) {
+				return 0, ErrInvalidTime
+			}
+			total = total*60 + lavalink.Duration(n)*lavalink.Second
+		}
+		return total, nil
+	}
+
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < 0 {
+			return 0, ErrInvalidTime
+		}
+		return lavalink.Duration(n) * lavalink.Second, nil
+	}
+
+	var total lavalink.Duration
+	num := ""
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			num += string(r)
+		case r == 'h' || r == 'm' || r == 's':
+			if num == "" {
+				return 0, ErrInvalidTime
+			}
+			n, _ := strconv.Atoi(num)
+			unit := lavalink.Second
+			if r == 'h' {
+				unit = lavalink.Hour
+			} else if r == 'm' {
+				unit = lavalink.Minute
+			}
+			total += lavalink.Duration(n) * unit
+			num = ""
+		default:
+			return 0, ErrInvalidTime
+		}
+	}
+	if num != "" {
+		return 0, ErrInvalidTime
+	}
+	return total, nil
+}
+
+// SeekTarget은 입력값을 현재 위치 기준으로 해석해 이동할 위치를 계산합니다.
+// "+30", "-15"처럼 부호가 붙으면 상대 위치, 그 외에는 절대 위치입니다.
+// 뒤로 가는 경우 0초에서 멈추고, 곡 길이를 넘으면 ErrSeekOutOfSpan을 반환합니다.
+func SeekTarget(input string, current lavalink.Duration, track lavalink.Track) (lavalink.Duration, error) {
+	// disgolink TrackInfo에는 isSeekable이 없으므로 스트림 여부로 판단
+	if track.Info.IsStream {
+		return 0, ErrNotSeekable
+	}
+
+	input = strings.TrimSpace(input)
+	sign := 0
+	if strings.HasPrefix(input, "+") {
+		sign = 1
+	} else if strings.HasPrefix(input, "-") {
+		sign = -1
+	}
+	if sign != 0 {
+		input = input[1:]
+	}
+
+	d, err := ParseTime(input)
+	if err != nil {
+		return 0, err
+	}
+
+	target := d
+	if sign != 0 {
+		target = current + lavalink.Duration(sign)*d
+	}
+	return ClampSeek(target, track)
+}
+
+// ClampSeek은 위치를 0 이상으로 맞추고 곡 길이를 넘는지 검사합니다.
+func ClampSeek(target lavalink.Duration, track lavalink.Track) (lavalink.Duration, error) {
+	if track.Info.IsStream {
+		return 0, ErrNotSeekable
+	}
+	if target < 0 {
+		target = 0
+	}
+	if target >= track.Info.Length {
+		return 0, ErrSeekOutOfSpan
+	}
+	return target, nil
+}
@@ -0,0 +1,113 @@
+package player
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/disgoorg/disgolink/v3/lavalink"
+)
+
+func TestParseTime(t *testing.T) {
+	tests := []struct {
+		input string
+		want  lavalink.Duration
+		err   error
+	}{
+		{input: "1:23", want: 83 * lavalink.Second},
+		{input: "1:02:03", want: lavalink.Hour + 2*lavalink.Minute + 3*lavalink.Second},
+		{input: "0:00", want: 0},
+		{input: "90", want: 90 * lavalink.Second},
+		{input: "90s", want: 90 * lavalink.Second},
+		{input: "1m30s", want: 90 * lavalink.Second},
+		{input: "1h2m3s", want: lavalink.Hour + 2*lavalink.Minute + 3*lavalink.Second},
+		{input: " 2M ", want: 2 * lavalink.Minute},
+		{input: "", err: ErrInvalidTime},
+		{input: "abc", err: ErrInvalidTime},
+		{input: "1:60", err: ErrInvalidTime},
+		{input: "1:2:3:4", err: ErrInvalidTime},
+		{input: "1:-5", err: ErrInvalidTime},
+		{input: "-5", err: ErrInvalidTime},
+		{input: "1m30", err: ErrInvalidTime},
+		{input: "m", err: ErrInvalidTime},
+		{input: "1x", err: ErrInvalidTime},
+	}
+	for _, tt := range tests {
+		got, err := ParseTime(tt.input)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("ParseTime(%q) error = %v, want %v", tt.input, err, tt.err)
+			continue
+		}
+		if err == nil && got != tt.want {
+			t.Errorf("ParseTime(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestSeekTarget(t *testing.T) {
+	song := lavalink.Track{Info: lavalink.TrackInfo{Length: 5 * lavalink.Minute}}
+	long := lavalink.Track{Info: lavalink.TrackInfo{Length: 2 * lavalink.Hour}}
+	stream := lavalink.Track{Info: lavalink.TrackInfo{IsStream: true}}
+	current := lavalink.Minute
+
+	tests := []struct {
+		name  string
+		input string
+		track lavalink.Track
+		want  lavalink.Duration
+		err   error
+	}{
+		{name: "분:초", input: "1:23", track: song, want: 83 * lavalink.Second},
+		{name: "시:분:초", input: "1:02:03", track: long, want: lavalink.Hour + 2*lavalink.Minute + 3*lavalink.Second},
+		{name: "초 단위", input: "90s", track: song, want: 90 * lavalink.Second},
+		{name: "숫자만", input: "90", track: song, want: 90 * lavalink.Second},
+		{name: "단위 조합", input: "1h2m3s", track: long, want: lavalink.Hour + 2*lavalink.Minute + 3*lavalink.Second},
+		{name: "앞으로", input: "+30", track: song, want: 90 * lavalink.Second},
+		{name: "뒤로", input: "-15", track: song, want: 45 * lavalink.Second},
+		{name: "0초 아래는 0초로", input: "-2m", track: song, want: 0},
+		{name: "잘못된 형식", input: "soon", track: song, err: ErrInvalidTime},
+		{name: "부호만", input: "+", track: song, err: ErrInvalidTime},
+		{name: "곡 길이와 같음", input: "5:00", track: song, err: ErrSeekOutOfSpan},
+		{name: "곡 길이를 넘음", input: "+10m", track: song, err: ErrSeekOutOfSpan},
+		{name: "스트림", input: "1:00", track: stream, err: ErrNotSeekable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SeekTarget(tt.input, current, tt.track)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("SeekTarget(%q) error = %v, want %v", tt.input, err, tt.err)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("SeekTarget(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClampSeek(t *testing.T) {
+	song := lavalink.Track{Info: lavalink.TrackInfo{Length: 3 * lavalink.Minute}}
+
+	tests := []struct {
+		name   string
+		target lavalink.Duration
+		track  lavalink.Track
+		want   lavalink.Duration
+		err    error
+	}{
+		{name: "범위 안", target: lavalink.Minute, track: song, want: lavalink.Minute},
+		{name: "음수는 0", target: -5 * lavalink.Second, track: song, want: 0},
+		{name: "마지막 직전", target: 3*lavalink.Minute - 1, track: song, want: 3*lavalink.Minute - 1},
+		{name: "곡 길이", target: 3 * lavalink.Minute, track: song, err: ErrSeekOutOfSpan},
+		{name: "스트림", target: 0, track: lavalink.Track{Info: lavalink.TrackInfo{IsStream: true}}, err: ErrNotSeekable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ClampSeek(tt.target, tt.track)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("ClampSeek(%d) error = %v, want %v", tt.target, err, tt.err)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("ClampSeek(%d) = %d, want %d", tt.target, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package player
+
+import (
+	"time"
+
+	"github.com/disgoorg/disgolink/v3/lavalink"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// SavedTrack은 Lavalink 인코딩 문자열과 봇이 붙인 UserData만 보관합니다.
+// 트랙 정보는 복원 시 노드에서 다시 디코딩합니다.
+type SavedTrack struct {
+	Encoded  string           `json:"encoded"`
+	UserData lavalink.RawData `json:"user_data,omitempty"`
+}
+
+func NewSavedTrack(track lavalink.Track) SavedTrack {
+	return SavedTrack{Encoded: track.Encoded, UserData: track.UserData}
+}
+
+// Snapshot은 재시작 후 복원하기 위한 길드 재생 상태입니다.
+type Snapshot struct {
+	GuildID        snowflake.ID      `json:"guild_id"`
+	VoiceChannelID snowflake.ID      `json:"voice_channel_id"`
+	TextChannelID  snowflake.ID      `json:"text_channel_id"`
+	CurrentTrack   *SavedTrack       `json:"current_track,omitempty"`
+	Position       lavalink.Duration `json:"position"`
+	Paused         bool              `json:"paused"`
+	Queue          []SavedTrack      `json:"queue"`
+	Volume         int               `json:"volume"`
+	Filters        FilterState       `json:"filters"`
+	Repeat         RepeatMode        `json:"repeat"`
+	QueueMode      QueueMode         `json:"queue_mode"`
+	SavedAt        time.Time         `json:"saved_at"`
+	// Notice는 종료할 때 남긴 재시작 안내 메시지이며, 복원한 뒤 삭제합니다.
+	NoticeChannelID snowflake.ID `json:"notice_channel_id,omitempty"`
+	NoticeMessageID snowflake.ID `json:"notice_message_id,omitempty"`
+}
+
+// Empty는 복원할 곡이 하나도 없는지 확인합니다.
+func (s Snapshot) Empty() bool {
+	return s.CurrentTrack == nil && len(s.Queue) == 0
+}
+
+// Snapshot은 현재 대기열과 설정을 복사합니다.
+// 재생 위치와 음성 채널은 Lavalink/Discord 쪽 상태이므로 호출자가 채웁니다.
+func (gp *GuildPlayer) Snapshot() Snapshot {
+	gp.Mu.Lock()
+	defer gp.Mu.Unlock()
+
+	s := Snapshot{
+		GuildID:       gp.GuildID,
+		TextChannelID: gp.TextChannelID,
+		Queue:         make([]SavedTrack, 0, len(gp.Queue)),
+		Volume:        gp.Volume,
+		Filters:       gp.Filters.clone(),
+		Repeat:        gp.Repeat,
+		QueueMode:     gp.QueueMode,
+		SavedAt:       time.Now(),
+	}
+	if gp.CurrentTrack != nil {
+		current := NewSavedTrack(*gp.CurrentTrack)
+		s.CurrentTrack = &current
+	}
+	for _, track := range gp.Queue {
+		s.Queue = append(s.Queue, NewSavedTrack(track))
+	}
+	return s
+}
+
+// Restore는 디코딩된 트랙으로 스냅샷 상태를 되살립니다.
+// OnChange는 호출하지 않습니다.
+func (gp *GuildPlayer) Restore(s Snapshot, current *lavalink.Track, queue []lavalink.Track) {
+	gp.Mu.Lock()
+	defer gp.Mu.Unlock()
+
+	gp.TextChannelID = s.TextChannelID
+	gp.CurrentTrack = current
+	gp.Queue = queue
+	gp.Volume = s.Volume
+	gp.Filters = s.Filters
+	gp.Repeat = s.Repeat
+	gp.QueueMode = s.QueueMode
+}
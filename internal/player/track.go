@@ -0,0 +1,55 @@
+package player
+
+import (
+	"time"
+
+	"github.com/disgoorg/disgolink/v3/lavalink"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// TrackData는 트랙의 UserData에 저장되는 봇 메타데이터입니다.
+// Lavalink가 TrackStart/TrackEnd 이벤트에 그대로 돌려주므로 재생 중에도 유지됩니다.
+type TrackData struct {
+	RequesterID snowflake.ID `json:"requester_id,omitempty"`
+	EnqueuedAt  time.Time    `json:"enqueued_at,omitempty"`
+	// Autoplay는 대기열이 비어 봇이 자동으로 고른 곡인지 여부입니다.
+	Autoplay bool `json:"autoplay,omitempty"`
+}
+
+// Data는 트랙에 저장된 메타데이터를 읽습니다. 없거나 손상된 경우 빈 값을 반환합니다.
+func Data(track lavalink.Track) TrackData {
+	var data TrackData
+	if len(track.UserData) > 0 {
+		_ = track.UserData.Unmarshal(&data)
+	}
+	return data
+}
+
+// WithData는 메타데이터를 설정한 트랙 복사본을 반환합니다.
+func WithData(track lavalink.Track, data TrackData) lavalink.Track {
+	if t, err := track.WithUserData(data); err == nil {
+		return t
+	}
+	return track
+}
+
+// WithRequester는 곡을 신청한 사용자와 신청 시각을 기록한 트랙 복사본을 반환합니다.
+func WithRequester(track lavalink.Track, userID snowflake.ID) lavalink.Track {
+	data := Data(track)
+	data.RequesterID = userID
+	data.EnqueuedAt = time.Now()
+	return WithData(track, data)
+}
+
+func Requester(track lavalink.Track) snowflake.ID {
+	return Data(track).RequesterID
+}
+
+// WithAutoplay는 자동 재생으로 고른 곡임을 표시한 트랙 복사본을 반환합니다.
+func WithAutoplay(track lavalink.Track) lavalink.Track {
+	return WithData(track, TrackData{EnqueuedAt: time.Now(), Autoplay: true})
+}
+
+func IsAutoplay(track lavalink.Track) bool {
+	return Data(track).Autoplay
+}
@@ -0,0 +1,239 @@
+package playlist
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/disgoorg/disgolink/v3/lavalink"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/uzih05/discord-music-bot/internal/store"
+)
+
+const bucket = "playlists"
+
+const (
+	MaxNameLength = 50
+	MaxPlaylists  = 25
+	MaxTracks     = 500
+)
+
+var (
+	ErrNotFound    = errors.New("플레이리스트를 찾을 수 없습니다")
+	ErrExists      = errors.New("같은 이름의 플레이리스트가 이미 있습니다")
+	ErrInvalidName = fmt.Errorf("플레이리스트 이름은 1-%d자여야 합니다", MaxNameLength)
+	ErrTooMany     = fmt.Errorf("플레이리스트는 %d개까지 만들 수 있습니다", MaxPlaylists)
+	ErrTooLong     = fmt.Errorf("플레이리스트에는 %d곡까지 저장할 수 있습니다", MaxTracks)
+	ErrEmpty       = errors.New("저장할 곡이 없습니다")
+)
+
+// Scope는 플레이리스트 소유 범위(개인 또는 서버)입니다.
+type Scope struct {
+	Guild bool
+	ID    snowflake.ID
+}
+
+func UserScope(userID snowflake.ID) Scope   { return Scope{ID: userID} }
+func GuildScope(guildID snowflake.ID) Scope { return Scope{Guild: true, ID: guildID} }
+
+func (s Scope) key() string {
+	if s.Guild {
+		return "guild-" + s.ID.String()
+	}
+	return "user-" + s.ID.String()
+}
+
+func (s Scope) String() string {
+	if s.Guild {
+		return "서버"
+	}
+	return "개인"
+}
+
+// Playlist는 저장된 곡 목록입니다.
+// 트랙은 Lavalink 인코딩 문자열과 정보를 함께 저장해 불러올 때 다시 검색하지 않습니다.
+type Playlist struct {
+	Name      string           `json:"name"`
+	OwnerID   snowflake.ID     `json:"owner_id"`
+	Tracks    []lavalink.Track `json:"tracks"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+// Duration은 스트림을 제외한 전체 재생 시간입니다.
+func (p Playlist) Duration() lavalink.Duration {
+	var total lavalink.Duration
+	for _, track := range p.Tracks {
+		if !track.Info.IsStream {
+			total += track.Info.Length
+		}
+	}
+	return total
+}
+
+// CleanTracks는 신청자 등 재생 중에 붙은 UserData를 지운 복사본을 반환합니다.
+func CleanTracks(tracks []lavalink.Track) []lavalink.Track {
+	result := make([]lavalink.Track, len(tracks))
+	for i, track := range tracks {
+		track.UserData = nil
+		result[i] = track
+	}
+	return result
+}
+
+// ValidName은 이름 앞뒤 공백을 제거하고 길이를 검사합니다.
+func ValidName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > MaxNameLength {
+		return "", ErrInvalidName
+	}
+	return name, nil
+}
+
+// Manager는 범위별 플레이리스트 목록을 저장소 한 항목으로 관리합니다.
+type Manager struct {
+	store store.Store
+	mu    sync.Mutex
+}
+
+func NewManager(st store.Store) *Manager {
+	return &Manager{store: st}
+}
+
+// List는 이름순으로 정렬된 플레이리스트를 반환합니다.
+func (m *Manager) List(scope Scope) ([]Playlist, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.load(scope)
+}
+
+func (m *Manager) Get(scope Scope, name string) (Playlist, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	lists, err := m.load(scope)
+	if err != nil {
+		return Playlist{}, err
+	}
+	i := index(lists, name)
+	if i < 0 {
+		return Playlist{}, ErrNotFound
+	}
+	return lists[i], nil
+}
+
+// Create는 새 플레이리스트를 만듭니다. 같은 이름이 있으면 ErrExists를 반환합니다.
+func (m *Manager) Create(scope Scope, p Playlist) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name, err := ValidName(p.Name)
+	if err != nil {
+		return err
+	}
+	if len(p.Tracks) == 0 {
+		return ErrEmpty
+	}
+	if len(p.Tracks) > MaxTracks {
+		return ErrTooLong
+	}
+
+	lists, err := m.load(scope)
+	if err != nil {
+		return err
+	}
+	if index(lists, name) >= 0 {
+		return ErrExists
+	}
+	if len(lists) >= MaxPlaylists {
+		return ErrTooMany
+	}
+
+	now := time.Now()
+	p.Name = name
+	p.Tracks = CleanTracks(p.Tracks)
+	p.CreatedAt = now
+	p.UpdatedAt = now
+	return m.save(scope, append(lists, p))
+}
+
+// Update는 fn으로 플레이리스트를 수정한 뒤 저장합니다. fn이 에러를 반환하면 저장하지 않습니다.
+func (m *Manager) Update(scope Scope, name string, fn func(p *Playlist) error) (Playlist, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	lists, err := m.load(scope)
+	if err != nil {
+		return Playlist{}, err
+	}
+	i := index(lists, name)
+	if i < 0 {
+		return Playlist{}, ErrNotFound
+	}
+
+	p := lists[i]
+	p.Tracks = slices.Clone(p.Tracks)
+	if err := fn(&p); err != nil {
+		return Playlist{}, err
+	}
+	if len(p.Tracks) > MaxTracks {
+		return Playlist{}, ErrTooLong
+	}
+	if p.Name, err = ValidName(p.Name); err != nil {
+		return Playlist{}, err
+	}
+	if j := index(lists, p.Name); j >= 0 && j != i {
+		return Playlist{}, ErrExists
+	}
+	p.Tracks = CleanTracks(p.Tracks)
+	p.UpdatedAt = time.Now()
+
+	lists[i] = p
+	return p, m.save(scope, lists)
+}
+
+func (m *Manager) Delete(scope Scope, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	lists, err := m.load(scope)
+	if err != nil {
+		return err
+	}
+	i := index(lists, name)
+	if i < 0 {
+		return ErrNotFound
+	}
+	return m.save(scope, slices.Delete(lists, i, i+1))
+}
+
+// load는 m.mu를 잡은 상태에서 호출해야 합니다.
+func (m *Manager) load(scope Scope) ([]Playlist, error) {
+	var lists []Playlist
+	if err := m.store.Get(bucket, scope.key(), &lists); err != nil && !errors.Is(err, store.ErrNotFound) {
+		return nil, err
+	}
+	return lists, nil
+}
+
+func (m *Manager) save(scope Scope, lists []Playlist) error {
+	if len(lists) == 0 {
+		return m.store.Delete(bucket, scope.key())
+	}
+	slices.SortFunc(lists, func(a, b Playlist) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+	return m.store.Put(bucket, scope.key(), lists)
+}
+
+// index는 대소문자를 구분하지 않고 이름으로 찾습니다.
+func index(lists []Playlist, name string) int {
+	name = strings.TrimSpace(name)
+	return slices.IndexFunc(lists, func(p Playlist) bool {
+		return strings.EqualFold(p.Name, name)
+	})
+}
@@ -0,0 +1,190 @@
+package queuefile
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/disgolink/v3/lavalink"
+)
+
+const (
+	// Version은 JSON 파일 형식 버전입니다. 형식이 바뀌면 올립니다.
+	Version = 1
+	// MaxTracks는 한 파일에서 가져올 수 있는 최대 곡 수입니다.
+	MaxTracks = 500
+	// MaxSize는 가져올 파일의 최대 크기(바이트)입니다.
+	MaxSize = 1 << 20
+)
+
+var (
+	ErrEmpty       = errors.New("파일에 곡이 없습니다")
+	ErrTooMany     = fmt.Errorf("한 번에 최대 %d곡까지 가져올 수 있습니다", MaxTracks)
+	ErrVersion     = errors.New("지원하지 않는 파일 버전입니다")
+	ErrInvalidFile = errors.New("JSON 또는 M3U 형식의 대기열 파일이 아닙니다")
+)
+
+// Track은 파일에 저장하는 곡 한 개입니다.
+// Encoded가 있으면 같은 Lavalink에서 다시 검색하지 않고 바로 재생할 수 있고,
+// 없거나 해석할 수 없으면 URI나 제목으로 다시 찾습니다.
+type Track struct {
+	Title    string `json:"title"`
+	Author   string `json:"author,omitempty"`
+	URI      string `json:"uri,omitempty"`
+	Duration int64  `json:"duration_ms"`
+	Stream   bool   `json:"stream,omitempty"`
+	Encoded  string `json:"encoded,omitempty"`
+}
+
+// Query는 Encoded를 쓸 수 없을 때 곡을 다시 찾기 위한 검색어입니다 (URI 우선).
+func (t Track) Query() string {
+	if t.URI != "" {
+		return t.URI
+	}
+	return strings.TrimSpace(t.Author + " " + t.Title)
+}
+
+// File은 내보낸 대기열입니다. 첫 곡은 내보낼 때 재생 중이던 곡입니다.
+type File struct {
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exported_at"`
+	Tracks     []Track   `json:"tracks"`
+}
+
+func New(tracks []lavalink.Track) File {
+	f := File{
+		Version:    Version,
+		ExportedAt: time.Now().UTC(),
+		Tracks:     make([]Track, len(tracks)),
+	}
+	for i, track := range tracks {
+		t := Track{
+			Title:    track.Info.Title,
+			Author:   track.Info.Author,
+			Duration: int64(track.Info.Length),
+			Stream:   track.Info.IsStream,
+			Encoded:  track.Encoded,
+		}
+		if track.Info.URI != nil {
+			t.URI = *track.Info.URI
+		}
+		f.Tracks[i] = t
+	}
+	return f
+}
+
+func (f File) JSON() ([]byte, error) {
+	return json.MarshalIndent(f, "", "  ")
+}
+
+// M3U는 다른 플레이어에서도 열 수 있는 확장 M3U 재생목록입니다.
+// URI가 없는 곡은 M3U로 표현할 수 없어 제외합니다.
+func (f File) M3U() []byte {
+	var buf bytes.Buffer
+	buf.WriteString("#EXTM3U\n")
+	for _, t := range f.Tracks {
+		if t.URI == "" {
+			continue
+		}
+		seconds := t.Duration / 1000
+		if t.Stream {
+			seconds = -1
+		}
+		title := t.Title
+		if t.Author != "" {
+			title = t.Author + " - " + t.Title
+		}
+		fmt.Fprintf(&buf, "#EXTINF:%d,%s\n%s\n", seconds, strings.ReplaceAll(title, "\n", " "), t.URI)
+	}
+	return buf.Bytes()
+}
+
+// Parse는 Export로 만든 JSON 파일이나 M3U 재생목록을 읽고 검증합니다.
+func Parse(data []byte) (*File, error) {
+	if len(data) > MaxSize {
+		return nil, fmt.Errorf("파일이 너무 큽니다 (최대 %dKB)", MaxSize/1024)
+	}
+
+	var f *File
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		f = &File{}
+		if err := json.Unmarshal(trimmed, f); err != nil {
+			return nil, ErrInvalidFile
+		}
+		if f.Version < 1 || f.Version > Version {
+			return nil, ErrVersion
+		}
+	} else {
+		var err error
+		if f, err = parseM3U(trimmed); err != nil {
+			return nil, err
+		}
+	}
+
+	tracks := f.Tracks[:0]
+	for _, t := range f.Tracks {
+		t.Title = strings.TrimSpace(t.Title)
+		t.URI = strings.TrimSpace(t.URI)
+		if t.Encoded == "" && t.Query() == "" {
+			continue
+		}
+		tracks = append(tracks, t)
+	}
+	f.Tracks = tracks
+
+	switch {
+	case len(f.Tracks) == 0:
+		return nil, ErrEmpty
+	case len(f.Tracks) > MaxTracks:
+		return nil, ErrTooMany
+	}
+	return f, nil
+}
+
+// parseM3U는 주석이 아닌 줄을 URI로, 바로 앞의 #EXTINF를 제목과 길이로 읽습니다.
+func parseM3U(data []byte) (*File, error) {
+	f := &File{Version: Version}
+	var pending Track
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	// 기본 버퍼(64KB)보다 긴 줄도 파일 크기 제한까지는 읽음
+	scanner.Buffer(make([]byte, 0, 64*1024), MaxSize)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "#EXTINF:"):
+			info, title, _ := strings.Cut(strings.TrimPrefix(line, "#EXTINF:"), ",")
+			pending.Title = strings.TrimSpace(title)
+			// #EXTINF:123 tvg-id="..." 처럼 길이 뒤에 속성이 붙는 경우가 있음
+			fields := strings.Fields(info)
+			if len(fields) == 0 {
+				continue
+			}
+			if seconds, err := strconv.ParseInt(fields[0], 10, 64); err == nil {
+				pending.Duration = max(seconds, 0) * 1000
+				pending.Stream = seconds < 0
+			}
+		case strings.HasPrefix(line, "#"):
+		case !strings.HasPrefix(line, "http://") && !strings.HasPrefix(line, "https://"):
+			// 로컬 파일 경로 등 봇이 열 수 없는 항목은 건너뜀
+			pending = Track{}
+		default:
+			pending.URI = line
+			if pending.Title == "" {
+				pending.Title = line
+			}
+			f.Tracks = append(f.Tracks, pending)
+			pending = Track{}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFile, err)
+	}
+	return f, nil
+}
@@ -0,0 +1,137 @@
+package queuefile
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/disgoorg/disgolink/v3/lavalink"
+)
+
+func testTracks() []lavalink.Track {
+	uri := func(s string) *string { return &s }
+	return []lavalink.Track{
+		{Encoded: "enc1", Info: lavalink.TrackInfo{Title: "첫 곡", Author: "가수", Length: 185_000, URI: uri("https://example.com/1")}},
+		{Encoded: "enc2", Info: lavalink.TrackInfo{Title: "라디오", IsStream: true, URI: uri("https://example.com/live")}},
+		{Encoded: "enc3", Info: lavalink.TrackInfo{Title: "URI 없음", Author: "누군가", Length: 60_000}},
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	want := New(testTracks())
+	data, err := want.JSON()
+	if err != nil {
+		t.Fatalf("JSON: %v", err)
+	}
+	got, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if got.Version != Version || !got.ExportedAt.Equal(want.ExportedAt) {
+		t.Errorf("Version/ExportedAt = %d/%v, want %d/%v", got.Version, got.ExportedAt, Version, want.ExportedAt)
+	}
+	if len(got.Tracks) != len(want.Tracks) {
+		t.Fatalf("곡 수 = %d, want %d", len(got.Tracks), len(want.Tracks))
+	}
+	for i := range want.Tracks {
+		if got.Tracks[i] != want.Tracks[i] {
+			t.Errorf("Tracks[%d] = %+v, want %+v", i, got.Tracks[i], want.Tracks[i])
+		}
+	}
+}
+
+func TestM3URoundTrip(t *testing.T) {
+	data := New(testTracks()).M3U()
+	got, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	// M3U에는 인코딩 문자열과 URI 없는 곡이 남지 않음
+	want := []Track{
+		{Title: "가수 - 첫 곡", URI: "https://example.com/1", Duration: 185_000},
+		{Title: "라디오", URI: "https://example.com/live", Stream: true},
+	}
+	if len(got.Tracks) != len(want) {
+		t.Fatalf("곡 수 = %d, want %d (%+v)", len(got.Tracks), len(want), got.Tracks)
+	}
+	for i := range want {
+		if got.Tracks[i] != want[i] {
+			t.Errorf("Tracks[%d] = %+v, want %+v", i, got.Tracks[i], want[i])
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		queries []string
+		err     error
+	}{
+		{name: "버전 0", data: `{"version":0,"tracks":[{"title":"a"}]}`, err: ErrVersion},
+		{name: "새 버전", data: fmt.Sprintf(`{"version":%d,"tracks":[{"title":"a"}]}`, Version+1), err: ErrVersion},
+		{name: "깨진 JSON", data: `{"version":1,`, err: ErrInvalidFile},
+		{name: "빈 대기열", data: `{"version":1,"tracks":[]}`, err: ErrEmpty},
+		{
+			name:    "인코딩 없으면 URI나 제목으로 검색",
+			data:    `{"version":1,"tracks":[{"title":"제목","author":"가수"},{"title":"x","uri":" https://example.com/a "}]}`,
+			queries: []string{"가수 제목", "https://example.com/a"},
+		},
+		{
+			name:    "인코딩도 검색어도 없는 곡은 제외",
+			data:    `{"version":1,"tracks":[{"title":"  "},{"encoded":"enc"}]}`,
+			queries: []string{""},
+		},
+		{name: "모든 곡을 찾을 수 없음", data: `{"version":1,"tracks":[{"title":""},{"uri":" "}]}`, err: ErrEmpty},
+		{name: "BOM이 붙은 JSON", data: "\xef\xbb\xbf" + `{"version":1,"tracks":[{"title":"a"}]}`, queries: []string{"a"}},
+		{
+			name:    "M3U 로컬 파일과 속성",
+			data:    "#EXTM3U\n#EXTINF:10 tvg-id=\"x\",제목\nC:\\music\\a.mp3\n#EXTINF:20,다음\nhttps://example.com/b\n",
+			queries: []string{"https://example.com/b"},
+		},
+		{name: "곡 없는 M3U", data: "#EXTM3U\n", err: ErrEmpty},
+		{name: "곡 수 초과", data: strings.Repeat("https://example.com/x\n", MaxTracks+1), err: ErrTooMany},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := Parse([]byte(tt.data))
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Parse error = %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			var queries []string
+			for _, track := range f.Tracks {
+				queries = append(queries, track.Query())
+			}
+			if strings.Join(queries, "|") != strings.Join(tt.queries, "|") {
+				t.Errorf("검색어 = %q, want %q", queries, tt.queries)
+			}
+		})
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	if _, err := Parse(make([]byte, MaxSize+1)); err == nil {
+		t.Error("MaxSize를 넘는 파일을 받아들였습니다")
+	}
+
+	// 기본 Scanner 버퍼(64KB)보다 긴 줄도 제한 안에서는 읽음
+	long := "https://example.com/" + strings.Repeat("a", 100*1024)
+	f, err := Parse([]byte("#EXTM3U\n" + long + "\nhttps://example.com/next\n"))
+	if err != nil {
+		t.Fatalf("긴 줄 Parse: %v", err)
+	}
+	if len(f.Tracks) != 2 || f.Tracks[0].URI != long {
+		t.Errorf("긴 줄 다음 곡까지 읽어야 합니다: %d곡", len(f.Tracks))
+	}
+
+	// 한 줄이 버퍼 한도를 넘으면 일부만 읽지 않고 오류를 반환
+	_, err = parseM3U([]byte("https://example.com/" + strings.Repeat("a", MaxSize)))
+	if !errors.Is(err, ErrInvalidFile) || !errors.Is(err, bufio.ErrTooLong) {
+		t.Errorf("parseM3U(한도 초과 줄) error = %v, want ErrInvalidFile, bufio.ErrTooLong", err)
+	}
+}
@@ -0,0 +1,77 @@
+package search
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/disgoorg/disgolink/v3/lavalink"
+)
+
+// Source는 검색어 앞에 붙이는 Lavalink 검색 접두사입니다.
+// Plugin이 true인 소스는 LavaSrc 등 Lavalink 플러그인이 설치되어 있어야 동작합니다.
+type Source struct {
+	Name   string
+	Label  string
+	Icon   string
+	Type   lavalink.SearchType
+	Plugin bool
+}
+
+const DefaultSource = "youtube"
+
+var Sources = []Source{
+	{Name: "youtube", Label: "YouTube", Icon: "🟥", Type: lavalink.SearchTypeYouTube},
+	{Name: "youtubemusic", Label: "YouTube Music", Icon: "🎵", Type: lavalink.SearchTypeYouTubeMusic},
+	{Name: "soundcloud", Label: "SoundCloud", Icon: "🟧", Type: lavalink.SearchTypeSoundCloud},
+	{Name: "spotify", Label: "Spotify", Icon: "🟩", Type: "spsearch", Plugin: true},
+	{Name: "deezer", Label: "Deezer", Icon: "🟪", Type: "dzsearch", Plugin: true},
+	{Name: "applemusic", Label: "Apple Music", Icon: "🍎", Type: "amsearch", Plugin: true},
+}
+
+// FindSource는 이름으로 소스를 찾고, 없으면 기본 소스(YouTube)를 반환합니다.
+func FindSource(name string) (Source, bool) {
+	i := slices.IndexFunc(Sources, func(s Source) bool { return s.Name == name })
+	if i < 0 {
+		return Sources[0], false
+	}
+	return Sources[i], true
+}
+
+// Query는 검색어에 소스 접두사를 붙입니다.
+// 사용자가 이미 "scsearch:"처럼 알려진 접두사를 붙였다면 그대로 사용합니다.
+func (s Source) Query(query string) string {
+	for _, src := range Sources {
+		if strings.HasPrefix(query, string(src.Type)+":") {
+			return query
+		}
+	}
+	return s.Type.Apply(query)
+}
+
+// trackSources는 Lavalink TrackInfo.SourceName별 표시 정보입니다.
+var trackSources = map[string]Source{
+	"youtube":    Sources[0],
+	"soundcloud": Sources[2],
+	"spotify":    Sources[3],
+	"deezer":     Sources[4],
+	"applemusic": Sources[5],
+	"bandcamp":   {Name: "bandcamp", Label: "Bandcamp", Icon: "🟦"},
+	"twitch":     {Name: "twitch", Label: "Twitch", Icon: "🟪"},
+	"http":       {Name: "http", Label: "HTTP", Icon: "🔗"},
+}
+
+// TrackSource는 트랙이 재생되는 플랫폼의 표시 정보입니다.
+func TrackSource(track lavalink.Track) Source {
+	if s, ok := trackSources[track.Info.SourceName]; ok {
+		return s
+	}
+	if track.Info.SourceName == "" {
+		return Source{Label: "알 수 없음", Icon: "🎶"}
+	}
+	return Source{Name: track.Info.SourceName, Label: track.Info.SourceName, Icon: "🎶"}
+}
+
+// String은 "🟥 YouTube"처럼 아이콘과 이름을 함께 표시합니다.
+func (s Source) String() string {
+	return s.Icon + " " + s.Label
+}
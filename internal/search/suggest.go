@@ -0,0 +1,119 @@
+package search
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/disgolink/v3/lavalink"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+const (
+	SuggestTTL      = 2 * time.Minute
+	SuggestDebounce = 400 * time.Millisecond
+)
+
+type suggestion struct {
+	tracks    []lavalink.Track
+	createdAt time.Time
+}
+
+// pendingSuggest는 디바운스를 기다리는 사용자의 마지막 요청입니다.
+type pendingSuggest struct {
+	timer *time.Timer
+	done  chan bool
+}
+
+// SuggestCache는 /play 자동완성 검색 결과를 "소스:검색어" 단위로 잠깐 보관합니다.
+type SuggestCache struct {
+	entries  map[string]suggestion
+	pending  map[snowflake.ID]*pendingSuggest
+	ttl      time.Duration
+	debounce time.Duration
+	now      func() time.Time
+	mu       sync.Mutex
+}
+
+func NewSuggestCache() *SuggestCache {
+	return &SuggestCache{
+		entries:  make(map[string]suggestion),
+		pending:  make(map[snowflake.ID]*pendingSuggest),
+		ttl:      SuggestTTL,
+		debounce: SuggestDebounce,
+		now:      time.Now,
+	}
+}
+
+// SuggestKey는 대소문자와 공백 차이를 무시한 캐시 키입니다.
+func SuggestKey(source Source, query string) string {
+	return source.Name + ":" + strings.ToLower(strings.Join(strings.Fields(query), " "))
+}
+
+func (c *SuggestCache) Get(key string) ([]lavalink.Track, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s, ok := c.entries[key]
+	if !ok || c.now().Sub(s.createdAt) > c.ttl {
+		return nil, false
+	}
+	return s.tracks, true
+}
+
+// Closest는 key의 가장 긴 접두사로 캐시된 결과를 찾습니다.
+// 입력 중인 검색어의 이전 결과를 보여줄 때 사용합니다.
+func (c *SuggestCache) Closest(key string) []lavalink.Track {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var best string
+	for k, s := range c.entries {
+		if c.now().Sub(s.createdAt) > c.ttl {
+			continue
+		}
+		if strings.HasPrefix(key, k) && len(k) > len(best) {
+			best = k
+		}
+	}
+	if best == "" {
+		return nil
+	}
+	return c.entries[best].tracks
+}
+
+func (c *SuggestCache) Set(key string, tracks []lavalink.Track) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for k, s := range c.entries {
+		if now.Sub(s.createdAt) > c.ttl {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = suggestion{tracks: tracks, createdAt: now}
+}
+
+// Debounce는 SuggestDebounce 뒤에 값을 보내는 채널을 반환합니다.
+// 그 사이 같은 사용자의 새 요청이 오면 이전 요청의 채널에는 바로 false를 보내므로,
+// 입력할 때마다 Lavalink에 검색 요청을 보내지 않고 마지막 요청만 검색합니다.
+func (c *SuggestCache) Debounce(userID snowflake.ID) <-chan bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if prev, ok := c.pending[userID]; ok && prev.timer.Stop() {
+		prev.done <- false
+	}
+
+	p := &pendingSuggest{done: make(chan bool, 1)}
+	p.timer = time.AfterFunc(c.debounce, func() {
+		c.mu.Lock()
+		if c.pending[userID] == p {
+			delete(c.pending, userID)
+		}
+		c.mu.Unlock()
+		p.done <- true
+	})
+	c.pending[userID] = p
+	return p.done
+}
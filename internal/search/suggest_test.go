@@ -0,0 +1,124 @@
+package search
+
+import (
+	"testing"
+	"time"
+
+	"github.com/disgoorg/disgolink/v3/lavalink"
+)
+
+func tracksOf(names ...string) []lavalink.Track {
+	tracks := make([]lavalink.Track, 0, len(names))
+	for _, name := range names {
+		tracks = append(tracks, lavalink.Track{Encoded: name})
+	}
+	return tracks
+}
+
+func firstEncoded(tracks []lavalink.Track) string {
+	if len(tracks) == 0 {
+		return ""
+	}
+	return tracks[0].Encoded
+}
+
+func TestSuggestKey(t *testing.T) {
+	yt := Source{Name: "youtube"}
+	if got, want := SuggestKey(yt, "  Hello   World "), "youtube:hello world"; got != want {
+		t.Errorf("SuggestKey = %q, want %q", got, want)
+	}
+	if SuggestKey(yt, "a") == SuggestKey(Source{Name: "soundcloud"}, "a") {
+		t.Error("소스가 다르면 키도 달라야 합니다")
+	}
+}
+
+func TestSuggestCacheTTL(t *testing.T) {
+	now := time.Now()
+	c := NewSuggestCache()
+	c.now = func() time.Time { return now }
+
+	c.Set("yt:old", tracksOf("old"))
+	if got, ok := c.Get("yt:old"); !ok || firstEncoded(got) != "old" {
+		t.Fatalf("Get = %v, %v, want old", got, ok)
+	}
+
+	now = now.Add(SuggestTTL)
+	if _, ok := c.Get("yt:old"); !ok {
+		t.Error("TTL과 같은 시간에는 아직 유효해야 합니다")
+	}
+
+	now = now.Add(time.Second)
+	if _, ok := c.Get("yt:old"); ok {
+		t.Error("TTL이 지난 결과를 반환했습니다")
+	}
+	if got := c.Closest("yt:old song"); got != nil {
+		t.Errorf("Closest가 만료된 결과 %v를 반환했습니다", got)
+	}
+
+	// Set은 만료된 항목을 정리함
+	c.Set("yt:new", tracksOf("new"))
+	if _, ok := c.entries["yt:old"]; ok {
+		t.Error("만료된 항목이 정리되지 않았습니다")
+	}
+}
+
+func TestSuggestCacheClosest(t *testing.T) {
+	c := NewSuggestCache()
+	c.Set("yt:ne", tracksOf("ne"))
+	c.Set("yt:new", tracksOf("new"))
+	c.Set("yt:newjeans", tracksOf("newjeans"))
+	c.Set("sc:new", tracksOf("sc"))
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{key: "yt:new", want: "new"},
+		{key: "yt:newj", want: "new"},
+		{key: "yt:newjeans hype", want: "newjeans"},
+		{key: "yt:n", want: ""},
+		{key: "yt:other", want: ""},
+		{key: "sc:news", want: "sc"},
+	}
+	for _, tt := range tests {
+		if got := firstEncoded(c.Closest(tt.key)); got != tt.want {
+			t.Errorf("Closest(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestSuggestCacheDebounce(t *testing.T) {
+	c := NewSuggestCache()
+	c.debounce = 20 * time.Millisecond
+
+	first := c.Debounce(1)
+	second := c.Debounce(1)
+	other := c.Debounce(2)
+
+	// 이전 요청은 기다리지 않고 바로 밀려남
+	select {
+	case ok := <-first:
+		if ok {
+			t.Error("밀려난 요청이 true를 받았습니다")
+		}
+	case <-time.After(c.debounce / 2):
+		t.Error("밀려난 요청이 디바운스가 끝날 때까지 기다렸습니다")
+	}
+
+	for name, ch := range map[string]<-chan bool{"마지막 요청": second, "다른 사용자": other} {
+		select {
+		case ok := <-ch:
+			if !ok {
+				t.Errorf("%s가 false를 받았습니다", name)
+			}
+		case <-time.After(time.Second):
+			t.Errorf("%s가 응답을 받지 못했습니다", name)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.pending) != 0 {
+		t.Errorf("끝난 요청이 남아 있습니다: %d", len(c.pending))
+	}
+}
@@ -0,0 +1,156 @@
+package settings
+
+import (
+	"errors"
+	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/uzih05/discord-music-bot/internal/store"
+)
+
+const bucket = "settings"
+
+// Guild는 길드별로 저장되는 봇 설정입니다.
+type Guild struct {
+	DJRoleID snowflake.ID `json:"dj_role_id,omitempty"`
+	// Permissions는 명령어/버튼 ID별로 허용할 역할 목록입니다. 항목이 없으면 기본 권한을 따릅니다.
+	Permissions map[string][]snowflake.ID `json:"permissions,omitempty"`
+
+	VoteSkip        bool `json:"vote_skip,omitempty"`
+	VoteSkipPercent int  `json:"vote_skip_percent,omitempty"`
+
+	// Autoplay가 켜져 있으면 대기열이 비었을 때 최근 재생 기록과 비슷한 곡을 이어서 재생합니다.
+	Autoplay bool `json:"autoplay,omitempty"`
+
+	// SearchSource는 /play에서 소스를 고르지 않았을 때 사용할 검색 소스 이름입니다 (search.Sources).
+	SearchSource string `json:"search_source,omitempty"`
+
+	// 아래 값들은 0이면 기본값을 사용합니다.
+	DefaultVolume int `json:"default_volume,omitempty"`
+	// IdleTimeoutMinutes가 IdleNever면 대기 상태에서 퇴장하지 않습니다.
+	IdleTimeoutMinutes int `json:"idle_timeout_minutes,omitempty"`
+	// AnnounceChannelID가 있으면 Now Playing과 대기 중 메시지를 명령어를 쓴 채널 대신 이 채널에 보냅니다.
+	AnnounceChannelID snowflake.ID `json:"announce_channel_id,omitempty"`
+	// MaxQueueLength와 MaxTrackMinutes는 0이면 제한이 없습니다.
+	MaxQueueLength  int `json:"max_queue_length,omitempty"`
+	MaxTrackMinutes int `json:"max_track_minutes,omitempty"`
+
+	// PersistentNowPlaying이 켜져 있으면 곡마다 Now Playing 메시지를 새로 보내지 않고 하나를 계속 수정합니다.
+	PersistentNowPlaying bool `json:"persistent_now_playing,omitempty"`
+
+	// RequestChannelID는 일반 메시지를 /play 검색어로 처리하는 음악 신청 채널입니다.
+	// RequestPanelID는 그 채널에 고정해 두고 계속 수정하는 패널 메시지입니다.
+	RequestChannelID snowflake.ID `json:"request_channel_id,omitempty"`
+	RequestPanelID   snowflake.ID `json:"request_panel_id,omitempty"`
+
+	// APITokenHash는 /api token으로 발급한 HTTP API 토큰의 해시입니다. 원문은 저장하지 않습니다.
+	APITokenHash string `json:"api_token_hash,omitempty"`
+}
+
+const (
+	// DefaultVoteSkipPercent는 투표 스킵 비율이 설정되지 않았을 때 사용하는 값입니다.
+	DefaultVoteSkipPercent = 50
+	DefaultVolume          = 50
+	DefaultIdleTimeout     = 3 * time.Minute
+	// IdleNever는 IdleTimeoutMinutes에 저장하는 "퇴장하지 않음" 값입니다.
+	IdleNever = -1
+)
+
+// Volume은 새 플레이어의 기본 볼륨입니다.
+func (g Guild) Volume() int {
+	if g.DefaultVolume <= 0 {
+		return DefaultVolume
+	}
+	return g.DefaultVolume
+}
+
+// IdleTimeout은 대기 상태에서 자동으로 퇴장할 때까지의 시간입니다.
+// 퇴장하지 않도록 설정했으면 false를 반환합니다.
+func (g Guild) IdleTimeout() (time.Duration, bool) {
+	switch {
+	case g.IdleTimeoutMinutes == IdleNever:
+		return 0, false
+	case g.IdleTimeoutMinutes <= 0:
+		return DefaultIdleTimeout, true
+	default:
+		return time.Duration(g.IdleTimeoutMinutes) * time.Minute, true
+	}
+}
+
+// MaxTrackDuration은 대기열에 넣을 수 있는 곡의 최대 길이이며, 0이면 제한이 없습니다.
+func (g Guild) MaxTrackDuration() time.Duration {
+	return time.Duration(g.MaxTrackMinutes) * time.Minute
+}
+
+// SkipPercent는 스킵에 필요한 청취자 비율(%)입니다.
+func (g Guild) SkipPercent() int {
+	if g.VoteSkipPercent <= 0 {
+		return DefaultVoteSkipPercent
+	}
+	return g.VoteSkipPercent
+}
+
+// SkipVotesNeeded는 청취자 수에 대해 스킵에 필요한 표 수를 계산합니다 (최소 1표).
+func (g Guild) SkipVotesNeeded(listeners int) int {
+	return max((listeners*g.SkipPercent()+99)/100, 1)
+}
+
+func (g Guild) clone() Guild {
+	g.Permissions = maps.Clone(g.Permissions)
+	for action, roles := range g.Permissions {
+		g.Permissions[action] = slices.Clone(roles)
+	}
+	return g
+}
+
+// Manager는 길드 설정을 메모리에 캐시하고 변경 시 저장소에 기록합니다.
+type Manager struct {
+	store  store.Store
+	guilds map[snowflake.ID]Guild
+	mu     sync.Mutex
+}
+
+func NewManager(st store.Store) *Manager {
+	return &Manager{
+		store:  st,
+		guilds: make(map[snowflake.ID]Guild),
+	}
+}
+
+func (m *Manager) Get(guildID snowflake.ID) Guild {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.load(guildID).clone()
+}
+
+// Update는 fn으로 설정을 수정한 뒤 저장합니다.
+func (m *Manager) Update(guildID snowflake.ID, fn func(g *Guild)) (Guild, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	g := m.load(guildID).clone()
+	fn(&g)
+	if err := m.store.Put(bucket, guildID.String(), g); err != nil {
+		return m.load(guildID).clone(), err
+	}
+	m.guilds[guildID] = g
+	return g.clone(), nil
+}
+
+// load는 m.mu를 잡은 상태에서 호출해야 합니다.
+func (m *Manager) load(guildID snowflake.ID) Guild {
+	if g, ok := m.guilds[guildID]; ok {
+		return g
+	}
+
+	var g Guild
+	if err := m.store.Get(bucket, guildID.String(), &g); err != nil && !errors.Is(err, store.ErrNotFound) {
+		slog.Error("길드 설정 읽기 실패", "guild", guildID, "error", err)
+	}
+	m.guilds[guildID] = g
+	return g
+}
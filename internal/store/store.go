@@ -0,0 +1,109 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ErrNotFound는 요청한 키가 저장소에 없을 때 반환됩니다.
+var ErrNotFound = errors.New("저장된 항목이 없습니다")
+
+// Store는 버킷/키 단위로 JSON 값을 저장하는 영속 저장소입니다.
+// 기본 구현은 FileStore이며, 다른 백엔드로 교체할 수 있습니다.
+type Store interface {
+	Get(bucket, key string, v any) error
+	Put(bucket, key string, v any) error
+	Delete(bucket, key string) error
+	Keys(bucket string) ([]string, error)
+}
+
+// FileStore는 dir/<bucket>/<key>.json 형태로 값을 저장합니다.
+type FileStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (fs *FileStore) path(bucket, key string) string {
+	return filepath.Join(fs.dir, bucket, key+".json")
+}
+
+func (fs *FileStore) Get(bucket, key string, v any) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	data, err := os.ReadFile(fs.path(bucket, key))
+	if errors.Is(err, os.ErrNotExist) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func (fs *FileStore) Put(bucket, key string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Join(fs.dir, bucket), 0o755); err != nil {
+		return err
+	}
+
+	// 쓰기 도중 종료되어도 기존 파일이 깨지지 않도록 임시 파일에 쓴 뒤 교체
+	path := fs.path(bucket, key)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (fs *FileStore) Delete(bucket, key string) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	err := os.Remove(fs.path(bucket, key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (fs *FileStore) Keys(bucket string) ([]string, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	entries, err := os.ReadDir(filepath.Join(fs.dir, bucket))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		keys = append(keys, strings.TrimSuffix(name, ".json"))
+	}
+	return keys, nil
+}
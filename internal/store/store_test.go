@@ -0,0 +1,146 @@
+package store_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/disgoorg/disgolink/v3/lavalink"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/uzih05/discord-music-bot/internal/player"
+	"github.com/uzih05/discord-music-bot/internal/store"
+)
+
+type item struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func newStore(t *testing.T) *store.FileStore {
+	t.Helper()
+	fs, err := store.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileStore: %v", err)
+	}
+	return fs
+}
+
+func TestFileStore(t *testing.T) {
+	fs := newStore(t)
+
+	var got item
+	if err := fs.Get("items", "a", &got); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("Get(없는 키) = %v, want ErrNotFound", err)
+	}
+	if keys, err := fs.Keys("items"); err != nil || len(keys) != 0 {
+		t.Fatalf("Keys(없는 버킷) = %v, %v, want 빈 목록", keys, err)
+	}
+
+	if err := fs.Put("items", "a", item{Name: "a", Count: 1}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := fs.Put("items", "b", item{Name: "b", Count: 2}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	// 같은 키에 다시 쓰면 덮어씀
+	if err := fs.Put("items", "a", item{Name: "a", Count: 3}); err != nil {
+		t.Fatalf("Put(덮어쓰기): %v", err)
+	}
+
+	if err := fs.Get("items", "a", &got); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if want := (item{Name: "a", Count: 3}); got != want {
+		t.Errorf("Get = %+v, want %+v", got, want)
+	}
+
+	keys, err := fs.Keys("items")
+	if err != nil {
+		t.Fatalf("Keys: %v", err)
+	}
+	slices.Sort(keys)
+	if want := []string{"a", "b"}; !slices.Equal(keys, want) {
+		t.Errorf("Keys = %v, want %v", keys, want)
+	}
+
+	if err := fs.Delete("items", "a"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := fs.Delete("items", "a"); err != nil {
+		t.Errorf("Delete(이미 삭제됨) = %v, want nil", err)
+	}
+	if err := fs.Get("items", "a", &got); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("Get(삭제 후) = %v, want ErrNotFound", err)
+	}
+	keys, _ = fs.Keys("items")
+	if want := []string{"b"}; !slices.Equal(keys, want) {
+		t.Errorf("Keys(삭제 후) = %v, want %v", keys, want)
+	}
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	fs := newStore(t)
+
+	track := func(encoded string, requester uint64) lavalink.Track {
+		return player.WithRequester(lavalink.Track{Encoded: encoded}, snowflake.ID(requester))
+	}
+
+	gp := player.NewGuildPlayer(1, 70)
+	gp.TextChannelID = 10
+	gp.Add(track("current", 100), track("q1", 200), track("q2", 100))
+	current := gp.Next()
+	gp.SetRepeat(player.RepeatAll)
+	if _, err := gp.TogglePreset("nightcore"); err != nil {
+		t.Fatalf("TogglePreset: %v", err)
+	}
+
+	want := gp.Snapshot()
+	want.VoiceChannelID = 20
+	want.Position = lavalink.Duration(42_000)
+	if err := fs.Put("players", want.GuildID.String(), want); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	var got player.Snapshot
+	if err := fs.Get("players", want.GuildID.String(), &got); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.GuildID != want.GuildID || got.VoiceChannelID != 20 || got.TextChannelID != 10 || got.Position != want.Position {
+		t.Errorf("스냅샷 기본 값이 다릅니다: got %+v, want %+v", got, want)
+	}
+	if got.CurrentTrack == nil || got.CurrentTrack.Encoded != current.Encoded {
+		t.Fatalf("CurrentTrack = %+v, want %q", got.CurrentTrack, current.Encoded)
+	}
+
+	// 복원 시 노드 디코딩을 대신해 저장된 인코딩 문자열과 UserData로 트랙을 되살림
+	decode := func(s player.SavedTrack) lavalink.Track {
+		return lavalink.Track{Encoded: s.Encoded, UserData: s.UserData}
+	}
+	restoredCurrent := decode(*got.CurrentTrack)
+	var queue []lavalink.Track
+	for _, s := range got.Queue {
+		queue = append(queue, decode(s))
+	}
+
+	restored := player.NewGuildPlayer(1, 100)
+	restored.Restore(got, &restoredCurrent, queue)
+
+	again := restored.Snapshot()
+	if again.Volume != 70 || again.Repeat != player.RepeatAll || again.QueueMode != want.QueueMode {
+		t.Errorf("복원한 설정이 다릅니다: got %+v", again)
+	}
+	if !slices.Equal(again.Filters.Presets, want.Filters.Presets) {
+		t.Errorf("Filters = %v, want %v", again.Filters.Presets, want.Filters.Presets)
+	}
+	if len(again.Queue) != len(want.Queue) {
+		t.Fatalf("Queue 길이 = %d, want %d", len(again.Queue), len(want.Queue))
+	}
+	for i := range want.Queue {
+		if again.Queue[i].Encoded != want.Queue[i].Encoded {
+			t.Errorf("Queue[%d] = %q, want %q", i, again.Queue[i].Encoded, want.Queue[i].Encoded)
+		}
+		if player.Requester(queue[i]) != player.Requester(lavalink.Track{UserData: want.Queue[i].UserData}) {
+			t.Errorf("Queue[%d] 신청자가 복원되지 않았습니다", i)
+		}
+	}
+}
@@ -43,5 +43,11 @@ func main() {
 	<-sig
 
 	slog.Info("봇을 종료합니다...")
+	go func() {
+		<-sig
+		slog.Warn("종료 정리를 기다리지 않고 바로 종료합니다")
+		os.Exit(1)
+	}()
 	b.Stop(ctx)
+	slog.Info("종료 완료")
 }